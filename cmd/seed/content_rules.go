@@ -0,0 +1,176 @@
+package main
+
+// Content rules catch docs that keep the contract headings but still carry scaffold gaps.
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	reasonEmptySection          = "empty_section"
+	reasonEmptyListField        = "empty_list_field"
+	reasonUnresolvedPlaceholder = "unresolved_placeholder"
+)
+
+// Placeholder ids identify starter text emitted from defaultScaffoldInput.
+const (
+	placeholderOneLiner         = "one-liner"
+	placeholderProblemStatement = "problem-statement"
+	placeholderSuccessCriteria  = "success-criteria"
+	placeholderRunCommand       = "run-command"
+	placeholderTimeline         = "timeline"
+	placeholderBudget           = "budget"
+	placeholderMustWorkWith     = "must-work-with"
+)
+
+type placeholderPolicy struct {
+	// MaxAgeDays turns pending placeholders into errors once they are older; zero disables the limit.
+	MaxAgeDays int `json:"max_age_days"`
+	// BlockGuardedCommit makes any pending placeholder an error when seed-test.sh runs from pre-commit.
+	BlockGuardedCommit bool `json:"block_guarded_commit"`
+}
+
+var (
+	placeholderIDPattern    = regexp.MustCompile(`seed:placeholder id=([a-z0-9-]*)`)
+	placeholderSincePattern = regexp.MustCompile(`since=([0-9]{4}-[0-9]{2}-[0-9]{2})`)
+	htmlCommentPattern      = regexp.MustCompile(`<!--[^>]*-->`)
+	shellMarkerPattern      = regexp.MustCompile(`#[[:space:]]*seed:placeholder.*$`)
+)
+
+type contractOptions struct {
+	today     time.Time
+	preCommit bool
+	// committed is HEAD in pre-commit runs, whose lock and integrity setting are
+	// trusted over the ones being committed; nil when there is no commit yet.
+	committed repoSource
+}
+
+func placeholderMarker(id, since string) string {
+	return fmt.Sprintf("seed:placeholder id=%s since=%s", id, since)
+}
+
+func checkContentRules(source repoSource, rules manifestSnapshot, opts contractOptions, report *layoutReport) {
+	for _, spec := range rules.NonEmptySections {
+		sectionFile, heading := splitHeadingSpec(spec)
+		content, err := source.readFile(sectionFile)
+		if err != nil {
+			continue
+		}
+		lines, found := sectionLines(string(content), heading)
+		if found && !sectionHasContent(lines) {
+			report.addError(reasonEmptySection, sectionFile, "Empty required section \"%s\" in %s", heading, sectionFile)
+		}
+	}
+
+	for _, spec := range rules.RequiredListFields {
+		fieldFile, rest := splitHeadingSpec(spec)
+		heading, field := splitHeadingSpec(rest)
+		content, err := source.readFile(fieldFile)
+		if err != nil {
+			continue
+		}
+		lines, found := sectionLines(string(content), heading)
+		if !found {
+			continue
+		}
+		switch listFieldState(lines, field) {
+		case "missing":
+			report.addError(reasonEmptyListField, fieldFile, "Missing required list field \"- %s:\" under \"%s\" in %s", field, heading, fieldFile)
+		case "empty":
+			report.addError(reasonEmptyListField, fieldFile, "Required list field \"- %s:\" has no value under \"%s\" in %s", field, heading, fieldFile)
+		}
+	}
+
+	checkPlaceholders(source, rules.PlaceholderPolicy, opts, report)
+}
+
+func checkPlaceholders(source repoSource, policy placeholderPolicy, opts contractOptions, report *layoutReport) {
+	today := civilDay(opts.today)
+	for _, doc := range seedDocs {
+		content, err := source.readFile(doc)
+		if err != nil {
+			continue
+		}
+		for index, line := range strings.Split(string(content), "\n") {
+			idMatch := placeholderIDPattern.FindStringSubmatch(line)
+			if idMatch == nil {
+				continue
+			}
+			age := 0
+			if sinceMatch := placeholderSincePattern.FindStringSubmatch(line); sinceMatch != nil {
+				if since, err := time.Parse("2006-01-02", sinceMatch[1]); err == nil {
+					age = int(today.Sub(since).Hours() / 24)
+				}
+			}
+
+			id, lineNumber := idMatch[1], index+1
+			switch {
+			case opts.preCommit && policy.BlockGuardedCommit:
+				report.addError(reasonUnresolvedPlaceholder, doc,
+					"Unresolved Seed placeholder \"%s\" in %s:%d must be replaced before commit", id, doc, lineNumber)
+			case policy.MaxAgeDays > 0 && age > policy.MaxAgeDays:
+				report.addError(reasonUnresolvedPlaceholder, doc,
+					"Unresolved Seed placeholder \"%s\" in %s:%d is %d days old (limit %d days)", id, doc, lineNumber, age, policy.MaxAgeDays)
+			default:
+				report.addInfo(reasonUnresolvedPlaceholder, doc,
+					"Note: Seed placeholder \"%s\" in %s:%d is %d days old (limit %d days)", id, doc, lineNumber, age, policy.MaxAgeDays)
+			}
+		}
+	}
+}
+
+// sectionLines returns the lines under "## <heading>" up to the next top-level or second-level heading.
+func sectionLines(content, heading string) ([]string, bool) {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if line != "## "+heading {
+			continue
+		}
+		section := make([]string, 0)
+		for _, next := range lines[i+1:] {
+			if strings.HasPrefix(next, "## ") || strings.HasPrefix(next, "# ") {
+				break
+			}
+			section = append(section, next)
+		}
+		return section, true
+	}
+	return nil, false
+}
+
+// sectionHasContent ignores blank lines and HTML comments.
+func sectionHasContent(lines []string) bool {
+	for _, line := range lines {
+		if strings.TrimSpace(htmlCommentPattern.ReplaceAllString(line, "")) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// listFieldState reports missing, empty, placeholder, or ok for a "- <field>:" line.
+func listFieldState(lines []string, field string) string {
+	prefix := "- " + field + ":"
+	for _, line := range lines {
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		value := strings.TrimPrefix(line, prefix)
+		value = htmlCommentPattern.ReplaceAllString(value, "")
+		value = shellMarkerPattern.ReplaceAllString(value, "")
+		if strings.TrimSpace(value) != "" {
+			return "ok"
+		}
+		if strings.Contains(line, "seed:placeholder") {
+			return "placeholder"
+		}
+		return "empty"
+	}
+	return "missing"
+}
+
+func civilDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContentRules(t *testing.T) {
+	requireGit(t)
+
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "guarded")
+	mustScaffoldGitRepo(t, target, profileGuarded, manifest)
+	seedTest := filepath.Join(target, ".seed", "seed-test.sh")
+
+	output, code := runCommandWithExit(t, exec.Command(seedTest, "--pre-commit"))
+	if code != 1 || !strings.Contains(output, `Unresolved Seed placeholder "run-command" in README.md:8 must be replaced before commit`) {
+		t.Fatalf("expected pre-commit to block fresh placeholders, exit=%d output=%s", code, output)
+	}
+
+	today := time.Now().Format("2006-01-02")
+	mustRewriteFile(t, filepath.Join(target, "README.md"), func(content string) string {
+		content = strings.ReplaceAll(content, "since="+today, "since=2000-01-01")
+		return strings.Replace(content, "POC - scaffolded and ready for implementation.", "", 1)
+	})
+	mustRewriteFile(t, filepath.Join(target, "CONTEXT.md"), func(content string) string {
+		return strings.Replace(content, "- Timeline: <!-- seed:placeholder id=timeline since="+today+" -->", "- Timeline:", 1)
+	})
+
+	output, code = runCommandWithExit(t, exec.Command(seedTest))
+	if code != 1 {
+		t.Fatalf("expected seed-test to fail on content gaps, exit=%d output=%s", code, output)
+	}
+	wantReasons := "SEED_TRIGGER_REASONS=empty_section,empty_list_field,unresolved_placeholder"
+	for _, want := range []string{
+		"SEED_ERRORS=5",
+		wantReasons,
+		`Empty required section "Current Status" in README.md`,
+		`Required list field "- Timeline:" has no value under "Constraints" in CONTEXT.md`,
+		`Unresolved Seed placeholder "one-liner" in README.md:3 is`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in seed-test output: %s", want, output)
+		}
+	}
+
+	rules, _, err := loadRepoRules(worktreeSource{root: target}, profileGuarded)
+	if err != nil {
+		t.Fatalf("load repo rules: %v", err)
+	}
+	report := validateContract(worktreeSource{root: target}, rules, contractOptions{today: time.Now()})
+	_, reasons, _ := report.outcome(rules.WarningsAsErrors)
+	if report.count(severityError) != 5 || "SEED_TRIGGER_REASONS="+strings.Join(reasons, ",") != wantReasons {
+		t.Fatalf("go rules disagree with seed-test: reasons=%v findings=%v", reasons, report.Findings)
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+// Decisions parsing turns DECISIONS.md history into structured entries for validation and agent queries.
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const commandDecisions = "decisions"
+
+const (
+	reasonInvalidDecision    = "invalid_decision_entry"
+	reasonIncompleteDecision = "incomplete_decision"
+	reasonDecisionOrder      = "decision_order"
+	reasonDuplicateDecision  = "duplicate_decision"
+)
+
+var (
+	decisionHeadingPattern = regexp.MustCompile(`^([0-9]{4}-[0-9]{2}-[0-9]{2}): (.+)$`)
+	decisionWhyNotPattern  = regexp.MustCompile(`^Why not ([^:]+):(.*)$`)
+)
+
+// docListOptions configures read-only listing commands for structured Seed docs.
+type docListOptions struct {
+	repoPath   string
+	jsonOutput bool
+}
+
+// docListFile resolves a structured doc from the repo's rules so renamed docs are listed too.
+func docListFile(repoPath, key string, pick func(manifestSnapshot) string) (string, error) {
+	source := worktreeSource{root: repoPath}
+	profile, err := inferSeedProfile(source)
+	if err != nil {
+		return "", err
+	}
+	rules, _, err := loadRepoRules(source, profile)
+	if err != nil {
+		return "", err
+	}
+	file := pick(rules)
+	if file == "" {
+		return "", fmt.Errorf("%s is not set in the %s profile rules", key, profile)
+	}
+	return file, nil
+}
+
+type decisionEntry struct {
+	Date     string           `json:"date"`
+	Title    string           `json:"title"`
+	Line     int              `json:"line"`
+	Context  string           `json:"context"`
+	Decision string           `json:"decision"`
+	WhyNot   []decisionWhyNot `json:"why_not,omitempty"`
+	// heading keeps the raw "### " text so malformed entries can be reported.
+	heading string
+}
+
+type decisionWhyNot struct {
+	Alternative string `json:"alternative"`
+	Reason      string `json:"reason"`
+}
+
+// parseDecisions reads "### " entries under "## History"; the entry format template is ignored.
+func parseDecisions(content string) []decisionEntry {
+	entries := make([]decisionEntry, 0)
+	inHistory := false
+	for index, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "## ") || strings.HasPrefix(line, "# ") {
+			inHistory = line == "## History"
+			continue
+		}
+		if !inHistory {
+			continue
+		}
+		if strings.HasPrefix(line, "### ") {
+			heading := strings.TrimPrefix(line, "### ")
+			entry := decisionEntry{Line: index + 1, heading: heading, Title: heading}
+			if match := decisionHeadingPattern.FindStringSubmatch(heading); match != nil {
+				entry.Date = match[1]
+				entry.Title = match[2]
+			}
+			entries = append(entries, entry)
+			continue
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		current := &entries[len(entries)-1]
+		switch {
+		case strings.HasPrefix(line, "Context:"):
+			current.Context = strings.TrimSpace(strings.TrimPrefix(line, "Context:"))
+		case strings.HasPrefix(line, "Decision:"):
+			current.Decision = strings.TrimSpace(strings.TrimPrefix(line, "Decision:"))
+		default:
+			if match := decisionWhyNotPattern.FindStringSubmatch(line); match != nil {
+				current.WhyNot = append(current.WhyNot, decisionWhyNot{
+					Alternative: strings.TrimSpace(match[1]),
+					Reason:      strings.TrimSpace(match[2]),
+				})
+			}
+		}
+	}
+	return entries
+}
+
+func checkDecisions(source repoSource, rules manifestSnapshot, report *layoutReport) {
+	if rules.DecisionsFile == "" {
+		return
+	}
+	content, err := source.readFile(rules.DecisionsFile)
+	if err != nil {
+		return
+	}
+
+	file := rules.DecisionsFile
+	previousDate := ""
+	firstSeen := map[string]int{}
+	for _, entry := range parseDecisions(string(content)) {
+		if entry.Date == "" {
+			report.addError(reasonInvalidDecision, file,
+				"Invalid decision heading in %s:%d: expected \"### YYYY-MM-DD: <title>\"", file, entry.Line)
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", entry.Date); err != nil {
+			report.addError(reasonInvalidDecision, file, "Invalid decision date \"%s\" in %s:%d", entry.Date, file, entry.Line)
+			continue
+		}
+		if entry.Context == "" {
+			report.addError(reasonIncompleteDecision, file,
+				"Decision \"%s\" in %s:%d is missing \"Context:\"", entry.Title, file, entry.Line)
+		}
+		if entry.Decision == "" {
+			report.addError(reasonIncompleteDecision, file,
+				"Decision \"%s\" in %s:%d is missing \"Decision:\"", entry.Title, file, entry.Line)
+		}
+		if previousDate != "" && entry.Date > previousDate {
+			report.addWarning(reasonDecisionOrder, file,
+				"Decision \"%s\" in %s:%d is newer than the entry above it; keep History reverse-chronological", entry.Title, file, entry.Line)
+		}
+		previousDate = entry.Date
+		if first, ok := firstSeen[entry.Title]; ok {
+			report.addWarning(reasonDuplicateDecision, file,
+				"Duplicate decision title \"%s\" in %s:%d (first at line %d)", entry.Title, file, entry.Line, first)
+		} else {
+			firstSeen[entry.Title] = entry.Line
+		}
+	}
+}
+
+func parseDecisionsArgs(opts options, args []string) (options, error) {
+	if len(args) == 0 {
+		return opts, errors.New("missing decisions subcommand (expected list)")
+	}
+	switch args[0] {
+	case "list":
+	case "-h", "--help":
+		opts.showHelp = true
+		return opts, nil
+	default:
+		return opts, fmt.Errorf("unknown decisions subcommand: %s", args[0])
+	}
+	return parseDocListArgs(opts, args[1:])
+}
+
+func parseDocListArgs(opts options, args []string) (options, error) {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		opts.docList.repoPath = args[0]
+		args = args[1:]
+	}
+	for _, arg := range args {
+		switch arg {
+		case "--json":
+			opts.docList.jsonOutput = true
+		case "-h", "--help":
+			opts.showHelp = true
+		default:
+			return opts, fmt.Errorf("unknown argument: %s", arg)
+		}
+	}
+	return opts, nil
+}
+
+func printDecisionsUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: seed decisions list [repo-path] [--json]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "List history entries from the repo's decisions_file, DECISIONS.md by default (newest first as written).")
+}
+
+func runDecisionsList(opts docListOptions, out io.Writer) error {
+	file, err := docListFile(opts.repoPath, "decisions_file", func(rules manifestSnapshot) string { return rules.DecisionsFile })
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(filepath.Join(opts.repoPath, filepath.FromSlash(file)))
+	if err != nil {
+		return fmt.Errorf("read %s: %w", file, err)
+	}
+	entries := parseDecisions(string(content))
+
+	if opts.jsonOutput {
+		encoded, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal decisions: %w", err)
+		}
+		fmt.Fprintln(out, string(encoded))
+		return nil
+	}
+
+	for _, entry := range entries {
+		date := entry.Date
+		if date == "" {
+			date = "??????????"
+		}
+		fmt.Fprintf(out, "%s  %s\n", date, entry.Title)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDecisionsRules(t *testing.T) {
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "llm")
+	mustScaffoldProfile(t, target, profileLLM, manifest)
+
+	mustRewriteFile(t, filepath.Join(target, "DECISIONS.md"), func(content string) string {
+		return content + `
+### 2999-01-01: Initialized from Seed
+Context: Out of order and duplicated.
+Decision: Keep it for the test.
+
+### 2025-02-30: Impossible date
+Context: Invalid calendar date.
+Decision: Reject it.
+
+### 2020-01-01: Missing decision line
+Context: Only context.
+`
+	})
+
+	entries := parseDecisions(mustReadFile(t, filepath.Join(target, "DECISIONS.md")))
+	if len(entries) != 4 || entries[0].Title != "Initialized from Seed" || len(entries[0].WhyNot) != 1 {
+		t.Fatalf("unexpected parsed decisions: %+v", entries)
+	}
+
+	rules, _, err := loadRepoRules(worktreeSource{root: target}, profileLLM)
+	if err != nil {
+		t.Fatalf("load repo rules: %v", err)
+	}
+	report := layoutReport{}
+	checkDecisions(worktreeSource{root: target}, rules, &report)
+	_, reasons, _ := report.outcome(false)
+	want := "decision_order,duplicate_decision,invalid_decision_entry,incomplete_decision"
+	if strings.Join(reasons, ",") != want || report.count(severityError) != 2 || report.count(severityWarning) != 2 {
+		t.Fatalf("unexpected decision findings: reasons=%v findings=%v", reasons, report.Findings)
+	}
+
+	var out bytes.Buffer
+	if err := runDecisionsList(docListOptions{repoPath: target, jsonOutput: true}, &out); err != nil {
+		t.Fatalf("decisions list: %v", err)
+	}
+	var listed []decisionEntry
+	if err := json.Unmarshal(out.Bytes(), &listed); err != nil || len(listed) != 4 || listed[3].Decision != "" {
+		t.Fatalf("unexpected decisions json (%v): %s", err, out.String())
+	}
+
+	// The listed file follows decisions_file in the repo's rules.
+	if err := os.MkdirAll(filepath.Join(target, "docs"), 0o755); err != nil {
+		t.Fatalf("mkdir docs: %v", err)
+	}
+	if err := os.Rename(filepath.Join(target, "DECISIONS.md"), filepath.Join(target, "docs", "DECISIONS.md")); err != nil {
+		t.Fatalf("move decisions: %v", err)
+	}
+	mustRewriteFile(t, filepath.Join(target, ".seed", "manifest.json"), func(content string) string {
+		return strings.Replace(content, `"decisions_file": "DECISIONS.md"`, `"decisions_file": "docs/DECISIONS.md"`, 1)
+	})
+	out.Reset()
+	if err := runDecisionsList(docListOptions{repoPath: target}, &out); err != nil || !strings.Contains(out.String(), "Initialized from Seed") {
+		t.Fatalf("decisions list should read docs/DECISIONS.md (%v): %s", err, out.String())
+	}
+}
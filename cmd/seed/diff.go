@@ -0,0 +1,231 @@
+package main
+
+// Drift reports compare a seeded repo's boilerplate sections with what this CLI renders today.
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const commandDiff = "diff"
+
+// boilerplateSections are rendered from profile alone and carry no project input,
+// so a difference means the repo predates the current Seed wording. Seed does not record
+// scaffold inputs, so a section that renders any input must not be listed here.
+var boilerplateSections = []string{
+	"DECISIONS.md::Entry Format",
+	"CONTEXT.md::POC Philosophy",
+	"CONTEXT.md::Upgrade Triggers",
+	"AGENTS.md::Working Rules",
+	"AGENTS.md::POC Guardrails",
+	"AGENTS.md::Upgrade Triggers",
+}
+
+const (
+	sectionCurrent = "current"
+	sectionStale   = "stale"
+	sectionMissing = "missing"
+)
+
+type diffOptions struct {
+	repoPath string
+}
+
+type sectionDrift struct {
+	Spec    string
+	State   string
+	Added   int
+	Removed int
+}
+
+func parseDiffArgs(opts options, args []string) (options, error) {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		opts.diff.repoPath = args[0]
+		args = args[1:]
+	}
+	for _, arg := range args {
+		switch arg {
+		case "-h", "--help":
+			opts.showHelp = true
+		default:
+			return opts, fmt.Errorf("unknown argument: %s", arg)
+		}
+	}
+	return opts, nil
+}
+
+func printDiffUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: seed diff [repo-path]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Show how a seeded repo's boilerplate sections differ from what this CLI would generate")
+	fmt.Fprintln(w, "for its profile. Only sections that do not depend on scaffold inputs are compared;")
+	fmt.Fprintln(w, "sections built from the project name, date or starter answers are user-authored and skipped.")
+	fmt.Fprintln(w, "Exits 1 when any boilerplate section is stale or missing.")
+}
+
+// runDiff prints per-file unified diffs that touch only boilerplate sections, then a summary.
+// The docs are rendered from default inputs, which boilerplate sections never read.
+func runDiff(opts diffOptions, out io.Writer) (int, error) {
+	source := worktreeSource{root: opts.repoPath}
+	profile, err := inferSeedProfile(source)
+	if err != nil {
+		return 1, err
+	}
+	manifest, err := loadCanonicalManifest()
+	if err != nil {
+		return 1, err
+	}
+	in, err := defaultScaffoldInput(opts.repoPath, profile)
+	if err != nil {
+		return 1, err
+	}
+	rules, err := manifestForProfile(manifest, profile)
+	if err != nil {
+		return 1, err
+	}
+
+	drifts := make([]sectionDrift, 0, len(boilerplateSections))
+	for _, file := range boilerplateFiles() {
+		current, err := source.readFile(file)
+		if err != nil {
+			for _, spec := range boilerplateSections {
+				if sectionFile, _ := splitHeadingSpec(spec); sectionFile == file {
+					drifts = append(drifts, sectionDrift{Spec: spec, State: sectionMissing})
+				}
+			}
+			continue
+		}
+		rendered := renderSeedDoc(file, in, rules)
+		updated := string(current)
+		for _, spec := range boilerplateSections {
+			sectionFile, heading := splitHeadingSpec(spec)
+			if sectionFile != file {
+				continue
+			}
+			drift := sectionDrift{Spec: spec, State: sectionMissing}
+			expected, _ := sectionLines(rendered, heading)
+			if next, before, ok := replaceSectionBody(updated, heading, trimBlankLines(expected)); ok {
+				drift.State = sectionCurrent
+				if next != updated {
+					drift.State = sectionStale
+					drift.Added, drift.Removed = countDiffLines(unifiedDiff("a", "b", strings.Join(before, "\n")+"\n", strings.Join(trimBlankLines(expected), "\n")+"\n"))
+				}
+				updated = next
+			}
+			drifts = append(drifts, drift)
+		}
+		fmt.Fprint(out, unifiedDiff("a/"+file, "b/"+file, string(current), updated))
+	}
+
+	behind := 0
+	added, removed := 0, 0
+	if len(drifts) > 0 {
+		fmt.Fprintln(out, "Boilerplate sections:")
+	}
+	for _, drift := range drifts {
+		detail := ""
+		if drift.State == sectionStale {
+			detail = fmt.Sprintf(" (+%d -%d)", drift.Added, drift.Removed)
+		}
+		if drift.State != sectionCurrent {
+			behind++
+		}
+		added += drift.Added
+		removed += drift.Removed
+		fmt.Fprintf(out, "  %-7s  %s%s\n", drift.State, drift.Spec, detail)
+	}
+
+	summary := fmt.Sprintf("%d of %d boilerplate sections differ from seed %s (+%d -%d lines)", behind, len(drifts), cliVersion, added, removed)
+	if repoRules, fromRepo, err := loadRepoRules(source, profile); err == nil && fromRepo && repoRules.SeedFormatVersion != manifest.SeedFormatVersion {
+		summary += fmt.Sprintf("; format %s, current %s", orDash(repoRules.SeedFormatVersion), manifest.SeedFormatVersion)
+	}
+	if profile == profileGuarded {
+		outdated := 0
+		for _, script := range guardedScripts {
+			if state, _ := inspectScript(opts.repoPath, script); state.State != scriptCurrent {
+				outdated++
+			}
+		}
+		summary += fmt.Sprintf("; %d of %d guarded scripts not current", outdated, len(guardedScripts))
+	}
+	fmt.Fprintf(out, "\nSummary (%s profile): %s\n", profile, summary)
+
+	if behind > 0 {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// boilerplateFiles lists the files named in boilerplateSections in first-seen order.
+func boilerplateFiles() []string {
+	files := make([]string, 0)
+	for _, spec := range boilerplateSections {
+		file, _ := splitHeadingSpec(spec)
+		if !containsString(files, file) {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// replaceSectionBody swaps the non-blank body of a "## heading" section and keeps
+// the blank lines around it, so only the boilerplate text itself shows up in a diff.
+// It also returns the body it replaced.
+func replaceSectionBody(content, heading string, body []string) (string, []string, bool) {
+	lines := strings.Split(content, "\n")
+	start := -1
+	for i, line := range lines {
+		if line == "## "+heading {
+			start = i + 1
+			break
+		}
+	}
+	if start < 0 {
+		return content, nil, false
+	}
+	end := start
+	for end < len(lines) && !strings.HasPrefix(lines[end], "## ") && !strings.HasPrefix(lines[end], "# ") {
+		end++
+	}
+	for start < end && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+	for end > start && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	before := append([]string{}, lines[start:end]...)
+
+	replaced := make([]string, 0, len(lines)-len(before)+len(body))
+	replaced = append(replaced, lines[:start]...)
+	replaced = append(replaced, body...)
+	replaced = append(replaced, lines[end:]...)
+	return strings.Join(replaced, "\n"), before, true
+}
+
+func trimBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// countDiffLines counts added and removed lines, skipping the two file header lines.
+func countDiffLines(diff string) (int, int) {
+	added, removed := 0, 0
+	lines := strings.Split(diff, "\n")
+	if len(lines) > 2 {
+		lines = lines[2:]
+	}
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			removed++
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiffReportsBoilerplateDrift(t *testing.T) {
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "llm")
+	mustScaffoldProfile(t, target, profileLLM, manifest)
+
+	var out bytes.Buffer
+	if code, err := runDiff(diffOptions{repoPath: target}, &out); err != nil || code != 0 {
+		t.Fatalf("fresh scaffold should have no drift, got %d %v\n%s", code, err, out.String())
+	}
+	if strings.Contains(out.String(), "@@") || !strings.Contains(out.String(), "0 of 6 boilerplate sections differ") {
+		t.Fatalf("unexpected diff output:\n%s", out.String())
+	}
+
+	// runDiff renders from default inputs, so compared sections must not depend on them.
+	for _, profile := range []string{profileCore, profileLLM, profileGuarded} {
+		rules, err := manifestForProfile(manifest, profile)
+		if err != nil {
+			t.Fatalf("profile rules: %v", err)
+		}
+		defaults, err := defaultScaffoldInput(target, profile)
+		if err != nil {
+			t.Fatalf("default input: %v", err)
+		}
+		answered := defaults
+		answered.ProjectName, answered.CreatedDate, answered.StatusLine = "Other Project", "2001-02-03", "Shipped."
+		answered.Placeholders = map[string]bool{}
+		for _, spec := range boilerplateSections {
+			file, heading := splitHeadingSpec(spec)
+			want, _ := sectionLines(renderSeedDoc(file, defaults, rules), heading)
+			got, _ := sectionLines(renderSeedDoc(file, answered, rules), heading)
+			if strings.Join(got, "\n") != strings.Join(want, "\n") {
+				t.Fatalf("%s (%s) depends on scaffold inputs", spec, profile)
+			}
+		}
+	}
+
+	mustRewriteFile(t, filepath.Join(target, "AGENTS.md"), func(content string) string {
+		content = strings.Replace(content, "- Update TODO.md when task state changes.\n", "", 1)
+		return strings.Replace(content, "- Applies to the full repository.", "- Applies to src/ only.", 1)
+	})
+	mustRewriteFile(t, filepath.Join(target, "CONTEXT.md"), func(content string) string {
+		content = strings.Replace(content, "## POC Philosophy", "## Philosophy", 1)
+		return strings.Replace(content, "## Constraints\n", "## Constraints\n\n- Hosting: on-prem\n", 1)
+	})
+	out.Reset()
+	code, err := runDiff(diffOptions{repoPath: target}, &out)
+	if err != nil || code != 1 {
+		t.Fatalf("expected exit 1 for drift, got %d %v\n%s", code, err, out.String())
+	}
+	for _, want := range []string{
+		"--- a/AGENTS.md",
+		"+- Update TODO.md when task state changes.",
+		"stale    AGENTS.md::Working Rules (+1 -0)",
+		"missing  CONTEXT.md::POC Philosophy",
+		"current  CONTEXT.md::Upgrade Triggers",
+		"2 of 6 boilerplate sections differ",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("diff output missing %q:\n%s", want, out.String())
+		}
+	}
+	for _, userEdit := range []string{"src/ only", "Hosting", "--- a/CONTEXT.md"} {
+		if strings.Contains(out.String(), userEdit) {
+			t.Fatalf("user-authored sections must not be diffed, found %q:\n%s", userEdit, out.String())
+		}
+	}
+}
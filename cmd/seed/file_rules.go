@@ -0,0 +1,104 @@
+package main
+
+// File rules match repo paths against globs, mirroring check_file_rules in seed-test.sh.
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	reasonMissingFileMatch = "missing_file_match"
+	reasonForbiddenFile    = "forbidden_file"
+)
+
+type fileRule struct {
+	// RequireAny is met when any repo file matches one of its globs.
+	RequireAny []string `json:"require_any,omitempty"`
+	// Forbid reports every repo file matching one of its globs.
+	Forbid []string `json:"forbid,omitempty"`
+	// Allow exempts files from Forbid, for rules such as "nothing under .seed/ but these".
+	Allow []string `json:"allow,omitempty"`
+
+	// compiled holds the globs compiled by loadRepoRules.
+	compiled *fileRuleMatchers
+}
+
+type fileRuleMatchers struct {
+	requireAny globMatcher
+	forbid     globMatcher
+	allow      globMatcher
+}
+
+// compile returns a copy of the rule that carries its compiled globs.
+func (r fileRule) compile() fileRule {
+	r.compiled = &fileRuleMatchers{
+		requireAny: compileGlobs(r.RequireAny),
+		forbid:     compileGlobs(r.Forbid),
+		allow:      compileGlobs(r.Allow),
+	}
+	return r
+}
+
+func (r fileRule) matchers() *fileRuleMatchers {
+	if r.compiled == nil {
+		return r.compile().compiled
+	}
+	return r.compiled
+}
+
+// matches reports whether path matches any require_any or forbid glob of the rule.
+func (r fileRule) matches(path string) bool {
+	matchers := r.matchers()
+	return matchers.requireAny.match(path) || matchers.forbid.match(path)
+}
+
+// checkFileRules runs the rules in name order against the sorted repo file list;
+// each finding names the rule and the glob that matched.
+func checkFileRules(source repoSource, rules manifestSnapshot, report *layoutReport) {
+	if len(rules.FileRules) == 0 {
+		return
+	}
+	files := append([]string{}, source.listFiles()...)
+	sort.Strings(files)
+	for _, name := range sortedKeys(rules.FileRules) {
+		rule := rules.FileRules[name]
+		matchers := rule.matchers()
+		if len(rule.RequireAny) > 0 && !anyPathMatches(files, matchers.requireAny) {
+			report.addError(reasonMissingFileMatch, "",
+				"File rule \"%s\" needs a file matching %s", name, strings.Join(rule.RequireAny, " or "))
+		}
+		for _, path := range files {
+			glob, ok := matchers.forbid.first(path)
+			if !ok || matchers.allow.match(path) {
+				continue
+			}
+			report.addError(reasonForbiddenFile, path, "File rule \"%s\" forbids %s (matches %s)", name, path, glob)
+		}
+	}
+}
+
+func anyPathMatches(paths []string, matcher globMatcher) bool {
+	for _, path := range paths {
+		if matcher.match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkFileRuleShapes reports rules that can never match anything.
+func checkFileRuleShapes(prefix string, fileRules map[string]fileRule) []manifestProblem {
+	problems := make([]manifestProblem, 0)
+	for _, name := range sortedKeys(fileRules) {
+		rule := fileRules[name]
+		pointer := prefix + "/file_rules/" + pointerToken(name)
+		switch {
+		case len(rule.RequireAny) == 0 && len(rule.Forbid) == 0:
+			problems = append(problems, manifestProblem{pointer, "rule needs require_any or forbid globs"})
+		case len(rule.Allow) > 0 && len(rule.Forbid) == 0:
+			problems = append(problems, manifestProblem{pointer + "/allow", fmt.Sprintf("allow only applies to forbid, which rule %q does not set", name)})
+		}
+	}
+	return problems
+}
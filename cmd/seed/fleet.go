@@ -0,0 +1,404 @@
+package main
+
+// Fleet validation gives one overview of drift across many seeded repos.
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+const commandFleet = "fleet"
+
+const fleetValidate = "validate"
+
+// statusError marks repos whose validation could not run at all.
+const statusError = "error"
+
+// fleetMaxDepth bounds how far below each root seeded repos are searched for.
+const fleetMaxDepth = 4
+
+type fleetOptions struct {
+	action     string
+	roots      []string
+	listFile   string
+	jobs       int
+	jsonOutput bool
+}
+
+type fleetRepoResult struct {
+	Path            string            `json:"path"`
+	Profile         string            `json:"profile,omitempty"`
+	FormatVersion   string            `json:"format_version,omitempty"`
+	Status          string            `json:"status"`
+	Errors          int               `json:"errors"`
+	Warnings        int               `json:"warnings"`
+	TriggerReasons  []string          `json:"trigger_reasons"`
+	OutdatedScripts []hookScriptState `json:"outdated_scripts"`
+	Error           string            `json:"error,omitempty"`
+}
+
+type fleetReport struct {
+	Repos   []fleetRepoResult `json:"repos"`
+	Summary map[string]int    `json:"summary"`
+}
+
+func parseFleetArgs(opts options, args []string) (options, error) {
+	if len(args) == 0 {
+		return opts, errors.New("missing fleet subcommand (expected validate)")
+	}
+	switch args[0] {
+	case fleetValidate:
+		opts.fleet.action = args[0]
+	case "-h", "--help":
+		opts.showHelp = true
+		return opts, nil
+	default:
+		return opts, fmt.Errorf("unknown fleet subcommand: %s", args[0])
+	}
+
+	args = args[1:]
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--json":
+			opts.fleet.jsonOutput = true
+		case "--list":
+			if i+1 >= len(args) {
+				return opts, errors.New("missing value for --list")
+			}
+			opts.fleet.listFile = args[i+1]
+			i++
+		case "--jobs":
+			if i+1 >= len(args) {
+				return opts, errors.New("missing value for --jobs")
+			}
+			jobs, err := strconv.Atoi(args[i+1])
+			if err != nil || jobs < 1 {
+				return opts, fmt.Errorf("invalid --jobs %q (expected a positive number)", args[i+1])
+			}
+			opts.fleet.jobs = jobs
+			i++
+		case "-h", "--help":
+			opts.showHelp = true
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return opts, fmt.Errorf("unknown argument: %s", args[i])
+			}
+			opts.fleet.roots = append(opts.fleet.roots, args[i])
+		}
+	}
+	if !opts.showHelp && len(opts.fleet.roots) == 0 && opts.fleet.listFile == "" {
+		return opts, errors.New("fleet validate needs at least one directory, glob, or --list file")
+	}
+	return opts, nil
+}
+
+func printFleetUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: seed fleet validate <dir|glob>... [--list <file>] [--jobs N] [--json]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Find seeded repos under each directory and validate them concurrently.")
+	fmt.Fprintln(w, "A directory is a seeded repo when it has .seed/manifest.json or all core Seed docs.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	fmt.Fprintln(w, "  --list   Read more directories from a file, one per line (# starts a comment)")
+	fmt.Fprintln(w, "  --jobs   Number of repos validated at once (default: number of CPUs)")
+	fmt.Fprintln(w, "  --json   Print a JSON report instead of a table")
+}
+
+// runFleet returns 1 when any repo fails or cannot be validated.
+func runFleet(opts fleetOptions, out io.Writer) (int, error) {
+	patterns, err := fleetPatterns(opts)
+	if err != nil {
+		return 1, err
+	}
+	repos, unmatched, err := discoverFleet(patterns)
+	if err != nil {
+		return 1, err
+	}
+
+	jobs := opts.jobs
+	if jobs == 0 {
+		jobs = runtime.NumCPU()
+	}
+	report := fleetReport{Repos: append(validateFleet(repos, jobs), unmatched...), Summary: map[string]int{}}
+	code := 0
+	for _, repo := range report.Repos {
+		report.Summary[repo.Status]++
+		if repo.Status == statusFail || repo.Status == statusError {
+			code = 1
+		}
+	}
+
+	if opts.jsonOutput {
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return 1, fmt.Errorf("marshal fleet report: %w", err)
+		}
+		fmt.Fprintln(out, string(encoded))
+		return code, nil
+	}
+	printFleetTable(out, report)
+	return code, nil
+}
+
+// fleetPatterns lists the directories and globs from the arguments, then the --list file.
+func fleetPatterns(opts fleetOptions) ([]string, error) {
+	patterns := append([]string{}, opts.roots...)
+	if opts.listFile != "" {
+		content, err := os.ReadFile(opts.listFile)
+		if err != nil {
+			return nil, fmt.Errorf("read --list file: %w", err)
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				patterns = append(patterns, line)
+			}
+		}
+	}
+	return patterns, nil
+}
+
+// discoverFleet expands each pattern and finds the seeded repos below it. A pattern
+// that names no directory or holds no seeded repo becomes a statusError row, so a
+// typo never reads as a clean fleet.
+func discoverFleet(patterns []string) ([]string, []fleetRepoResult, error) {
+	seen := map[string]bool{}
+	repos := make([]string, 0)
+	unmatched := make([]fleetRepoResult, 0)
+	for _, pattern := range patterns {
+		roots := []string{pattern}
+		problem := "no seeded repos found"
+		if strings.ContainsAny(pattern, "*?[") {
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+			}
+			roots = matches
+			if len(matches) == 0 {
+				problem = "glob matches nothing"
+			}
+		} else if info, err := os.Stat(pattern); err != nil {
+			roots = nil
+			problem = "no such directory"
+			if !errors.Is(err, fs.ErrNotExist) {
+				problem = err.Error()
+			}
+		} else if !info.IsDir() {
+			roots = nil
+			problem = "not a directory"
+		}
+
+		found := discoverSeededRepos(roots)
+		if len(found) == 0 {
+			unmatched = append(unmatched, fleetRepoResult{Path: pattern, Status: statusError, Error: problem,
+				TriggerReasons: []string{}, OutdatedScripts: []hookScriptState{}})
+		}
+		for _, repo := range found {
+			if !seen[repo] {
+				seen[repo] = true
+				repos = append(repos, repo)
+			}
+		}
+	}
+	sort.Strings(repos)
+	return repos, unmatched, nil
+}
+
+// discoverSeededRepos returns each seeded repo once, without descending into repos it found.
+func discoverSeededRepos(roots []string) []string {
+	seen := map[string]bool{}
+	repos := make([]string, 0)
+	for _, root := range roots {
+		root = filepath.Clean(root)
+		rootDepth := strings.Count(root, string(filepath.Separator))
+		_ = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || !entry.IsDir() {
+				return nil
+			}
+			if path != root && containsString(scanPrunedDirs, entry.Name()) {
+				return filepath.SkipDir
+			}
+			if isSeededRepo(path) {
+				if !seen[path] {
+					seen[path] = true
+					repos = append(repos, path)
+				}
+				return filepath.SkipDir
+			}
+			if strings.Count(path, string(filepath.Separator))-rootDepth >= fleetMaxDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+	}
+	sort.Strings(repos)
+	return repos
+}
+
+func isSeededRepo(path string) bool {
+	source := worktreeSource{root: path}
+	if source.isFile(".seed/manifest.json") {
+		return true
+	}
+	for _, doc := range seedDocs {
+		if !source.isFile(doc) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateFleet runs at most jobs validations at once; results keep the order of repos.
+func validateFleet(repos []string, jobs int) []fleetRepoResult {
+	results := make([]fleetRepoResult, len(repos))
+	indexes := make(chan int)
+	done := make(chan struct{})
+	for worker := 0; worker < min(jobs, len(repos)); worker++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for i := range indexes {
+				results[i] = validateFleetRepo(repos[i])
+			}
+		}()
+	}
+	for i := range repos {
+		indexes <- i
+	}
+	close(indexes)
+	for worker := 0; worker < min(jobs, len(repos)); worker++ {
+		<-done
+	}
+	return results
+}
+
+// validateFleetRepo never panics: a broken repo becomes a statusError row.
+func validateFleetRepo(path string) (result fleetRepoResult) {
+	result = fleetRepoResult{Path: path, TriggerReasons: []string{}, OutdatedScripts: []hookScriptState{}}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result.Status = statusError
+			result.Error = fmt.Sprintf("validation panicked: %v", recovered)
+		}
+	}()
+
+	source := worktreeSource{root: path}
+	profile, err := inferSeedProfile(source)
+	if err != nil {
+		result.Status = statusError
+		result.Error = err.Error()
+		return result
+	}
+	result.Profile = profile
+	var snapshot manifestSnapshot
+	if bytes, err := source.readFile(".seed/manifest.json"); err == nil {
+		if err := json.Unmarshal(bytes, &snapshot); err != nil {
+			result.Status = statusError
+			result.Error = fmt.Sprintf("parse .seed/manifest.json: %s", err)
+			return result
+		}
+		result.FormatVersion = snapshot.SeedFormatVersion
+	}
+	if profile == profileGuarded {
+		for _, script := range guardedScripts {
+			if state, _ := inspectScript(path, script); state.State != scriptCurrent {
+				result.OutdatedScripts = append(result.OutdatedScripts, state)
+			}
+		}
+	}
+
+	var out, errOut bytes.Buffer
+	code := runValidateLayout(validateLayoutOptions{repoPath: path, profile: profile, profileSet: true}, &out, &errOut)
+	fields := parseSeedStatus(out.String())
+	result.Status = fields["SEED_STATUS"]
+	result.Errors, _ = strconv.Atoi(fields["SEED_ERRORS"])
+	result.Warnings, _ = strconv.Atoi(fields["SEED_WARNINGS"])
+	if reasons := fields["SEED_TRIGGER_REASONS"]; reasons != "" && reasons != "none" {
+		result.TriggerReasons = strings.Split(reasons, ",")
+	}
+	if result.Status == "" {
+		// The validator stopped before printing a status, for example on a missing guarded artifact.
+		result.Status = statusError
+		result.Error = firstLine(errOut.String())
+		if result.Error == "" {
+			result.Error = fmt.Sprintf("validate-layout exited %d without a status", code)
+		}
+		return result
+	}
+	return result
+}
+
+// parseSeedStatus reads the SEED_STATUS, SEED_ERRORS, SEED_WARNINGS and SEED_TRIGGER_REASONS lines.
+func parseSeedStatus(output string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		switch key {
+		case "SEED_STATUS", "SEED_ERRORS", "SEED_WARNINGS", "SEED_TRIGGER_REASONS":
+			if ok {
+				fields[key] = value
+			}
+		}
+	}
+	return fields
+}
+
+func firstLine(text string) string {
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+func printFleetTable(out io.Writer, report fleetReport) {
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "REPO\tPROFILE\tFORMAT\tSTATUS\tERRORS\tWARNINGS\tSCRIPTS")
+	for _, repo := range report.Repos {
+		scripts := "-"
+		if repo.Profile == profileGuarded {
+			scripts = "current"
+			if len(repo.OutdatedScripts) > 0 {
+				names := make([]string, 0, len(repo.OutdatedScripts))
+				for _, script := range repo.OutdatedScripts {
+					names = append(names, filepath.Base(script.Path)+" "+script.State)
+				}
+				scripts = strings.Join(names, ", ")
+			}
+		}
+		format := orDash(repo.FormatVersion)
+		if containsString(repo.TriggerReasons, reasonFormatOutdated) {
+			format += " (outdated)"
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%d\t%d\t%s\n",
+			repo.Path, orDash(repo.Profile), format, repo.Status, repo.Errors, repo.Warnings, scripts)
+	}
+	table.Flush()
+
+	for _, repo := range report.Repos {
+		if repo.Error != "" {
+			fmt.Fprintf(out, "%s: %s\n", repo.Path, repo.Error)
+		}
+	}
+	fmt.Fprintf(out, "\n%d repos: %d ok, %d skill_recommended, %d fail, %d error\n", len(report.Repos),
+		report.Summary[statusOK], report.Summary[statusSkillRecommended], report.Summary[statusFail], report.Summary[statusError])
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFleetValidate(t *testing.T) {
+	requireGit(t)
+
+	manifest := mustLoadManifest(t)
+	root := t.TempDir()
+	mustScaffoldProfile(t, filepath.Join(root, "core"), profileCore, manifest)
+	mustScaffoldProfile(t, filepath.Join(root, "team", "llm"), profileLLM, manifest)
+	guarded := filepath.Join(root, "team", "guarded")
+	mustScaffoldGitRepo(t, guarded, profileGuarded, manifest)
+	mustRewriteFile(t, filepath.Join(guarded, ".seed", "seed-test.sh"), func(content string) string {
+		return content + "# local tweak\n"
+	})
+	if err := os.Remove(filepath.Join(root, "team", "llm", "TODO.md")); err != nil {
+		t.Fatalf("remove TODO.md: %v", err)
+	}
+	broken := filepath.Join(root, "broken", ".seed")
+	if err := os.MkdirAll(broken, 0o755); err != nil {
+		t.Fatalf("mkdir broken: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(broken, "manifest.json"), []byte("{"), 0o644); err != nil {
+		t.Fatalf("write broken manifest: %v", err)
+	}
+	// Unseeded directories and pruned dependency trees are not reported.
+	mustScaffoldProfile(t, filepath.Join(root, "core", "node_modules", "dep"), profileCore, manifest)
+	if err := os.MkdirAll(filepath.Join(root, "notes"), 0o755); err != nil {
+		t.Fatalf("mkdir notes: %v", err)
+	}
+
+	var out bytes.Buffer
+	code, err := runFleet(fleetOptions{roots: []string{root}, jobs: 2, jsonOutput: true}, &out)
+	if err != nil || code != 1 {
+		t.Fatalf("expected exit 1 without error, got %d %v\n%s", code, err, out.String())
+	}
+	var report fleetReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("parse fleet JSON: %v\n%s", err, out.String())
+	}
+	got := make(map[string]fleetRepoResult)
+	for _, repo := range report.Repos {
+		rel, _ := filepath.Rel(root, repo.Path)
+		got[filepath.ToSlash(rel)] = repo
+	}
+	if len(got) != 4 {
+		t.Fatalf("expected 4 repos, got %+v", report.Repos)
+	}
+	if repo := got["core"]; repo.Profile != profileCore || repo.Status != statusOK {
+		t.Fatalf("unexpected core row: %+v", repo)
+	}
+	if repo := got["team/llm"]; repo.Status != statusFail || repo.Errors != 1 || !containsString(repo.TriggerReasons, reasonMissingFile) {
+		t.Fatalf("unexpected llm row: %+v", repo)
+	}
+	if repo := got["team/guarded"]; repo.Status != statusOK || repo.FormatVersion != manifest.SeedFormatVersion ||
+		len(repo.OutdatedScripts) != 1 || repo.OutdatedScripts[0].State != scriptModified {
+		t.Fatalf("unexpected guarded row: %+v", repo)
+	}
+	if repo := got["broken"]; repo.Status != statusError || !strings.Contains(repo.Error, "parse .seed/manifest.json") {
+		t.Fatalf("a broken repo should be reported, not stop the run: %+v", repo)
+	}
+	if report.Summary[statusOK] != 2 || report.Summary[statusFail] != 1 || report.Summary[statusError] != 1 {
+		t.Fatalf("unexpected summary: %+v", report.Summary)
+	}
+
+	list := filepath.Join(root, "repos.txt")
+	if err := os.WriteFile(list, []byte("# fleet\n"+filepath.Join(root, "core")+"\n"), 0o644); err != nil {
+		t.Fatalf("write list: %v", err)
+	}
+	out.Reset()
+	code, err = runFleet(fleetOptions{roots: []string{filepath.Join(root, "team", "g*")}, listFile: list}, &out)
+	if err != nil || code != 0 {
+		t.Fatalf("expected clean run for glob and list file, got %d %v\n%s", code, err, out.String())
+	}
+	for _, want := range []string{"REPO", "seed-test.sh modified", "2 repos: 2 ok, 0 skill_recommended, 0 fail, 0 error"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("fleet table missing %q:\n%s", want, out.String())
+		}
+	}
+
+	// Roots and globs that name no seeded repo are rows, not silently skipped.
+	out.Reset()
+	missing := filepath.Join(root, "missing")
+	nothing := filepath.Join(root, "nothing-*")
+	empty := filepath.Join(root, "notes")
+	code, err = runFleet(fleetOptions{roots: []string{missing, nothing, empty}, jsonOutput: true}, &out)
+	if err != nil || code != 1 {
+		t.Fatalf("expected exit 1 for roots without repos, got %d %v\n%s", code, err, out.String())
+	}
+	report = fleetReport{}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("parse fleet JSON: %v\n%s", err, out.String())
+	}
+	wantErrors := map[string]string{missing: "no such directory", nothing: "glob matches nothing", empty: "no seeded repos found"}
+	if len(report.Repos) != len(wantErrors) || report.Summary[statusError] != len(wantErrors) {
+		t.Fatalf("expected one error row per root: %+v", report)
+	}
+	for _, repo := range report.Repos {
+		if repo.Status != statusError || repo.Error != wantErrors[repo.Path] {
+			t.Fatalf("unexpected row for %s: %+v", repo.Path, repo)
+		}
+	}
+
+	// A guarded repo still running its 2.0.0 seed-test.sh is flagged by the CLI's format check.
+	old := filepath.Join(t.TempDir(), "old")
+	mustScaffoldGitRepo(t, old, profileGuarded, manifest)
+	for _, path := range []string{"seed-test.sh", "install-hooks.sh", "hooks/pre-commit"} {
+		legacy := mustReadFile(t, filepath.Join("testdata", "guarded-2.0.0", filepath.FromSlash(path)))
+		mustRewriteFile(t, filepath.Join(old, ".seed", filepath.FromSlash(path)), func(string) string { return legacy })
+	}
+	legacyManifest := mustReadFile(t, filepath.Join("testdata", "manifest-2.0.0-guarded.json"))
+	mustRewriteFile(t, filepath.Join(old, ".seed", "manifest.json"), func(string) string { return legacyManifest })
+	out.Reset()
+	if _, err := runFleet(fleetOptions{roots: []string{old}}, &out); err != nil {
+		t.Fatalf("fleet on 2.0.0 repo: %v", err)
+	}
+	if !strings.Contains(out.String(), "2.0.0 (outdated)") || strings.Contains(out.String(), "1 ok") {
+		t.Fatalf("fleet table should flag the outdated format:\n%s", out.String())
+	}
+}
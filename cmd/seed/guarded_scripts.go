@@ -2,12 +2,166 @@ package main
 
 // Guarded profile artifacts are emitted as shell scripts so seeded repos stay self-contained.
 
+type guardedScript struct {
+	path    string
+	content string
+}
+
+// guardedScripts lists every generated guarded script in scaffold order.
+var guardedScripts = []guardedScript{
+	{path: ".seed/seed-test.sh", content: guardedSeedTestScript},
+	{path: ".seed/hooks/pre-commit", content: guardedPreCommitHookScript},
+	{path: ".seed/hooks/pre-push", content: guardedPrePushHookScript},
+	{path: ".seed/install-hooks.sh", content: guardedInstallHooksScript},
+}
+
 const guardedSeedTestScript = `#!/usr/bin/env sh
 set -eu
 
 repo_root=$(CDPATH= cd -- "$(dirname -- "$0")/.." && pwd)
 cd "$repo_root"
 
+pre_commit="false"
+source="worktree"
+revision=""
+today=$(date +%Y-%m-%d)
+while [ "$#" -gt 0 ]; do
+  case "$1" in
+    --pre-commit) pre_commit="true" ;;
+    --staged) source="index" ;;
+    --worktree) source="worktree" ;;
+    --rev)
+      if [ "$#" -lt 2 ]; then
+        printf 'Missing value for --rev\n' >&2
+        exit 64
+      fi
+      source="revision"
+      revision=$2
+      shift
+      ;;
+    *)
+      printf 'Unknown seed-test.sh argument: %s\n' "$1" >&2
+      exit 64
+      ;;
+  esac
+  shift
+done
+
+# Directories skipped at any depth by the misplaced-content scan; keep in sync
+# with the find prune list in list_scan_files and scanPrunedDirs in the CLI.
+default_scan_prune=".git node_modules vendor dist build target .venv"
+scan_include=""
+scan_comment_include=""
+scan_exclude=""
+
+# glob_regex_awk defines glob_regex for the awk programs that match paths:
+# * and ? stay within one path segment; ** spans any number of segments,
+# including none when written as **/.
+glob_regex_awk='
+    function glob_regex(glob,    out, i, n, c) {
+      out = "^"
+      n = length(glob)
+      for (i = 1; i <= n; i++) {
+        c = substr(glob, i, 1)
+        if (substr(glob, i, 3) == "**/") {
+          out = out "(.*/)?"
+          i += 2
+        } else if (substr(glob, i, 2) == "**") {
+          out = out ".*"
+          i++
+        } else if (c == "*") {
+          out = out "[^/]*"
+        } else if (c == "?") {
+          out = out "[^/]"
+        } else if (index("\\.[]()|+{}^$", c) > 0) {
+          out = out "\\" c
+        } else {
+          out = out c
+        }
+      }
+      return out "$"
+    }
+'
+
+# scan_scope_filter keeps paths on stdin that match one of the include globs in
+# $1 and no exclude glob.
+scan_scope_filter() {
+  SEED_SCAN_INCLUDE=$1 SEED_SCAN_EXCLUDE=$scan_exclude SEED_SCAN_PRUNE=$default_scan_prune awk "$glob_regex_awk"'
+    BEGIN {
+      include_count = split(ENVIRON["SEED_SCAN_INCLUDE"], include_globs, "\n")
+      for (i = 1; i <= include_count; i++) include_re[i] = glob_regex(include_globs[i])
+      exclude_count = split(ENVIRON["SEED_SCAN_EXCLUDE"], exclude_globs, "\n")
+      for (i = 1; i <= exclude_count; i++) exclude_re[i] = glob_regex(exclude_globs[i])
+      prune_count = split(ENVIRON["SEED_SCAN_PRUNE"], prune_dirs, " ")
+      for (i = 1; i <= prune_count; i++) exclude_re[exclude_count + i] = glob_regex("**/" prune_dirs[i] "/**")
+      exclude_count += prune_count
+    }
+    {
+      path = $0
+      sub(/^\.\//, "", path)
+      for (i = 1; i <= exclude_count; i++) if (path ~ exclude_re[i]) next
+      for (i = 1; i <= include_count; i++) {
+        if (path ~ include_re[i]) {
+          print path
+          next
+        }
+      }
+    }
+  '
+}
+
+# list_scan_files uses git ls-files in the worktree so .gitignore applies;
+# snapshots and trees outside git fall back to find.
+list_scan_files() {
+  if [ "$source" = "worktree" ] && git rev-parse --is-inside-work-tree >/dev/null 2>&1; then
+    git ls-files --cached --others --exclude-standard
+  else
+    find . \( -name .git -o -name node_modules -o -name vendor -o -name dist -o -name build -o -name target -o -name .venv \) -prune -o -type f -print
+  fi
+}
+
+# With --staged or --rev, export Seed files from the index or a commit into a
+# scratch tree so unstaged edits can neither rescue nor break the result.
+# Revisions are read into a throwaway index; the real index is never touched.
+if [ "$source" != "worktree" ]; then
+  scratch_dir=$(mktemp -d "${TMPDIR:-/tmp}/seed-snapshot.XXXXXX")
+  trap 'rm -rf "$scratch_dir"' EXIT
+  snapshot_dir="$scratch_dir/tree"
+  mkdir "$snapshot_dir"
+  if [ "$source" = "revision" ]; then
+    GIT_INDEX_FILE="$scratch_dir/index"
+    export GIT_INDEX_FILE
+    if ! git read-tree "$revision^{tree}" 2>/dev/null; then
+      printf 'Unknown revision: %s\n' "$revision" >&2
+      exit 64
+    fi
+    # Placeholder ages are measured against the commit date, as in validate-layout --rev.
+    today=$(git log -1 --format=%cd --date=short "$revision")
+  fi
+  git ls-files --cached -- ':(glob)*.md' '.seed' 'skills' |
+    git checkout-index -q --prefix="$snapshot_dir/" --stdin
+  # File rules only need paths, so they read the full list instead of the export.
+  snapshot_files=$(git ls-files --cached)
+  snapshot_rules="$snapshot_dir/.seed/rules.tsv"
+  if [ -f "$snapshot_rules" ]; then
+    awk -F '\t' '$1 == "required_files" { print $2 }' "$snapshot_rules" |
+      git checkout-index -q --prefix="$snapshot_dir/" --stdin 2>/dev/null || true
+    # Export the misplaced-content scan scope too, so it sees the same files.
+    scan_include=$(awk -F '\t' '$1 == "misplaced_content_scope.include" { print $2 }' "$snapshot_rules")
+    if [ -z "$scan_include" ]; then
+      scan_include='**/*.md'
+    fi
+    scan_comment_include=$(awk -F '\t' '$1 == "misplaced_content_scope.comment_include" { print $2 }' "$snapshot_rules")
+    scan_exclude=$(awk -F '\t' '$1 == "misplaced_content_scope.exclude" { print $2 }' "$snapshot_rules")
+    tracked_files=$(git ls-files --cached)
+    {
+      printf '%s\n' "$tracked_files" | scan_scope_filter "$scan_include"
+      printf '%s\n' "$tracked_files" | scan_scope_filter "$scan_comment_include"
+    } | git checkout-index -q --prefix="$snapshot_dir/" --stdin 2>/dev/null || true
+  fi
+  cd "$snapshot_dir"
+fi
+
 manifest_path=".seed/manifest.json"
 if [ ! -f "$manifest_path" ]; then
   printf 'SEED_STATUS=fail\n'
@@ -18,6 +172,19 @@ if [ ! -f "$manifest_path" ]; then
   exit 1
 fi
 
+# Rules are read from .seed/rules.tsv, a flattened copy of manifest.json that
+# the Seed CLI generates: one "<key><TAB><value>" line per scalar or array item,
+# with nested keys joined by dots.
+rules_path=".seed/rules.tsv"
+if [ ! -f "$rules_path" ]; then
+  printf 'SEED_STATUS=fail\n'
+  printf 'SEED_ERRORS=1\n'
+  printf 'SEED_WARNINGS=0\n'
+  printf 'SEED_TRIGGER_REASONS=missing_rules\n'
+  printf 'Missing Seed rules file: %s (run seed refresh-scripts to generate it from %s)\n' "$rules_path" "$manifest_path" >&2
+  exit 1
+fi
+
 errors=0
 warnings=0
 trigger_reasons="none"
@@ -35,63 +202,602 @@ add_reason() {
   esac
 }
 
-json_array_values() {
-  key=$1
-  awk -v key="$key" '
-    BEGIN { in_array=0 }
-    {
-      if (in_array == 0) {
-        if ($0 ~ "\\\"" key "\\\"[[:space:]]*:[[:space:]]*\\[") {
-          in_array=1
-          next
+# rule_values prints every value recorded for a key, one per line.
+rule_values() {
+  awk -F '\t' -v key="$1" '$1 == key { print $2 }' "$rules_path"
+}
+
+# rule_value prints the first value recorded for a key.
+rule_value() {
+  awk -F '\t' -v key="$1" '$1 == key { print $2; exit }' "$rules_path"
+}
+
+file_sha256() {
+  if command -v sha256sum >/dev/null 2>&1; then
+    sha256sum "$1" | awk '{ print $1 }'
+  elif command -v shasum >/dev/null 2>&1; then
+    shasum -a 256 "$1" | awk '{ print $1 }'
+  fi
+}
+
+# heading_near_miss prints the closest "## " heading in a file whose normalized
+# form (case folded, punctuation and spaces stripped) is within the manifest
+# edit-distance thresholds. Known headings are never offered as renames.
+heading_near_miss() {
+  SEED_EXPECTED=$1 SEED_KNOWN=$3 awk -v max_distance="$near_miss_max_distance" -v max_ratio="$near_miss_max_ratio" '
+    function normalize(s) {
+      s = tolower(s)
+      gsub(/[^a-z0-9]/, "", s)
+      return s
+    }
+    function distance(a, b,    la, lb, i, j, cost, v, prev, cur) {
+      la = length(a)
+      lb = length(b)
+      if (la == 0) return lb
+      if (lb == 0) return la
+      for (j = 0; j <= lb; j++) prev[j] = j
+      for (i = 1; i <= la; i++) {
+        cur[0] = i
+        for (j = 1; j <= lb; j++) {
+          cost = (substr(a, i, 1) == substr(b, j, 1)) ? 0 : 1
+          v = prev[j] + 1
+          if (cur[j - 1] + 1 < v) v = cur[j - 1] + 1
+          if (prev[j - 1] + cost < v) v = prev[j - 1] + cost
+          cur[j] = v
         }
+        for (j = 0; j <= lb; j++) prev[j] = cur[j]
+      }
+      return prev[lb]
+    }
+    BEGIN {
+      target = normalize(ENVIRON["SEED_EXPECTED"])
+      count = split(ENVIRON["SEED_KNOWN"], known_list, "\n")
+      for (k = 1; k <= count; k++) known[known_list[k]] = 1
+      best = -1
+    }
+    /^## / {
+      name = substr($0, 4)
+      if (target == "" || (name in known)) next
+      d = distance(target, normalize(name))
+      if (d > max_distance + 0) next
+      if (max_ratio + 0 > 0 && d > (max_ratio + 0) * length(target)) next
+      if (best < 0 || d < best) {
+        best = d
+        found = name
+      }
+    }
+    END {
+      if (best >= 0) print found
+    }
+  ' "$2"
+}
+
+near_miss_max_distance=$(rule_value "heading_near_miss.max_edit_distance")
+near_miss_max_ratio=$(rule_value "heading_near_miss.max_distance_ratio")
+if [ -z "$near_miss_max_distance" ]; then
+  near_miss_max_distance=0
+fi
+if [ -z "$near_miss_max_ratio" ]; then
+  near_miss_max_ratio=0
+fi
+
+warnings_as_errors=$(rule_value "warnings_as_errors")
+if [ -z "$warnings_as_errors" ]; then
+  warnings_as_errors="false"
+fi
+
+placeholder_max_age=$(rule_value "placeholder_policy.max_age_days")
+if [ -z "$placeholder_max_age" ]; then
+  placeholder_max_age=0
+fi
+placeholder_block_commit=$(rule_value "placeholder_policy.block_guarded_commit")
+
+tab=$(printf '\t')
+
+# Severities come from the manifest's rule_severities and, taking precedence,
+# from .seed/overrides.json. The CLI validates the overrides and writes them to
+# rules.tsv as "overrides."-prefixed lines, or as one overrides.invalid line
+# with the problem, and records their checksum on the second header line.
+# Overrides edited since are ignored and reported until the next refresh.
+overrides_path=".seed/overrides.json"
+overrides_recorded=$(sed -n '2s/^# seed:overrides overrides-sha256=//p' "$rules_path")
+overrides_actual=""
+if [ -f "$overrides_path" ]; then
+  overrides_actual=$(file_sha256 "$overrides_path")
+fi
+overrides_stale="false"
+if [ -n "$overrides_actual" ] || [ ! -f "$overrides_path" ]; then
+  if [ "$overrides_actual" != "$overrides_recorded" ]; then
+    overrides_stale="true"
+  fi
+fi
+overrides_rules=""
+if [ "$overrides_stale" = "false" ]; then
+  overrides_rules=$(awk -F '\t' 'index($1, "overrides.") == 1 { print substr($1, 11) "\t" $2 }' "$rules_path")
+fi
+
+# override_values prints the values recorded for a key in the overrides.
+override_values() {
+  printf '%s\n' "$overrides_rules" | awk -F '\t' -v key="$1" '$1 == key { print $2 }'
+}
+
+overrides_problem=$(override_values "invalid")
+exempt_files=$(override_values "exempt_files")
+
+# rule_severity prints the configured severity for a finding reason, if any.
+rule_severity() {
+  severity_override=$(override_values "severities.$1")
+  if [ -n "$severity_override" ]; then
+    printf '%s\n' "$severity_override"
+  else
+    rule_value "rule_severities.$1"
+  fi
+}
+
+# glob_match reports whether path $1 matches one of the globs in $2.
+glob_match() {
+  SEED_GLOBS=$2 awk -v path="$1" "$glob_regex_awk"'
+    BEGIN {
+      count = split(ENVIRON["SEED_GLOBS"], globs, "\n")
+      for (i = 1; i <= count; i++) if (path ~ glob_regex(globs[i])) exit 0
+      exit 1
+    }
+  '
+}
+
+# report_finding prints and counts one finding: $1 severity, $2 reason, $3 file
+# (may be empty), $4 message. Findings about exempt files and rules set to off
+# are dropped; info notes keep their severity whatever the rule is raised to.
+# Integrity findings bypass it so overrides cannot silence them.
+report_finding() {
+  if [ -n "$3" ] && [ -n "$exempt_files" ] && glob_match "$3" "$exempt_files"; then
+    return 0
+  fi
+  finding_level=$(rule_severity "$2")
+  case "$finding_level" in
+    off) return 0 ;;
+    "") finding_level=$1 ;;
+  esac
+  if [ "$1" = "info" ]; then
+    finding_level="info"
+  fi
+  case "$finding_level" in
+    error)
+      errors=$((errors + 1))
+      add_reason "$2"
+      ;;
+    warning)
+      warnings=$((warnings + 1))
+      add_reason "$2"
+      ;;
+  esac
+  printf '%s\n' "$4" >&2
+}
+
+# report_findings reports the "<severity><TAB><reason><TAB><message>" lines a
+# rule checker emitted for file $1.
+report_findings() {
+  for finding in $2; do
+    finding_severity=${finding%%"$tab"*}
+    finding_rest=${finding#*"$tab"}
+    finding_reason=${finding_rest%%"$tab"*}
+    finding_message=${finding_rest#*"$tab"}
+    report_finding "$finding_severity" "$finding_reason" "$1" "$finding_message"
+  done
+}
+
+# check_decisions validates "### YYYY-MM-DD: <title>" entries under "## History".
+check_decisions() {
+  SEED_FILE=$1 awk '
+    function valid_date(date,    y, m, d, limit) {
+      if (date !~ /^[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]$/) return 0
+      y = substr(date, 1, 4) + 0
+      m = substr(date, 6, 2) + 0
+      d = substr(date, 9, 2) + 0
+      if (m < 1 || m > 12 || d < 1) return 0
+      limit = 31
+      if (m == 4 || m == 6 || m == 9 || m == 11) limit = 30
+      if (m == 2) limit = ((y % 4 == 0 && y % 100 != 0) || y % 400 == 0) ? 29 : 28
+      return d <= limit
+    }
+    function finish_entry() {
+      if (!in_entry) return
+      in_entry = 0
+      if (context == "") printf "error\tincomplete_decision\tDecision \"%s\" in %s:%d is missing \"Context:\"\n", title, file, entry_line
+      if (decision == "") printf "error\tincomplete_decision\tDecision \"%s\" in %s:%d is missing \"Decision:\"\n", title, file, entry_line
+      if (previous_date != "" && date > previous_date) printf "warning\tdecision_order\tDecision \"%s\" in %s:%d is newer than the entry above it; keep History reverse-chronological\n", title, file, entry_line
+      previous_date = date
+      if (title in first_seen) {
+        printf "warning\tduplicate_decision\tDuplicate decision title \"%s\" in %s:%d (first at line %d)\n", title, file, entry_line, first_seen[title]
       } else {
-        if ($0 ~ /\]/) {
-          exit
+        first_seen[title] = entry_line
+      }
+    }
+    function trimmed(value) {
+      sub(/^[[:space:]]+/, "", value)
+      sub(/[[:space:]]+$/, "", value)
+      return value
+    }
+    BEGIN { file = ENVIRON["SEED_FILE"] }
+    /^## / || /^# / {
+      finish_entry()
+      in_history = ($0 == "## History")
+      next
+    }
+    !in_history { next }
+    /^### / {
+      finish_entry()
+      heading = substr($0, 5)
+      if (heading !~ /^[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]: ./) {
+        printf "error\tinvalid_decision_entry\tInvalid decision heading in %s:%d: expected \"### YYYY-MM-DD: <title>\"\n", file, NR
+        next
+      }
+      date = substr(heading, 1, 10)
+      if (!valid_date(date)) {
+        printf "error\tinvalid_decision_entry\tInvalid decision date \"%s\" in %s:%d\n", date, file, NR
+        next
+      }
+      in_entry = 1
+      entry_line = NR
+      title = substr(heading, 13)
+      context = ""
+      decision = ""
+      next
+    }
+    /^Context:/ { context = trimmed(substr($0, 9)) }
+    /^Decision:/ { decision = trimmed(substr($0, 10)) }
+    END { finish_entry() }
+  ' "$1"
+}
+
+# check_todo validates TODO.md section presence and order, checkbox syntax, the done cap, and BLOCKERS.
+check_todo() {
+  SEED_FILE=$1 SEED_TODO_SECTIONS=$todo_sections SEED_TODO_CHECKBOX=$todo_checkbox_sections \
+    SEED_TODO_BLOCKERS=$todo_blockers_section SEED_TODO_DONE=$todo_done_section \
+    awk -v done_limit="$todo_done_limit" '
+    function trimmed(value) {
+      sub(/^[[:space:]]+/, "", value)
+      sub(/[[:space:]]+$/, "", value)
+      return value
+    }
+    function item_text(raw,    text) {
+      text = trimmed(substr(raw, 3))
+      if (length(text) >= 4 && substr(text, 1, 2) == "~~" && substr(text, length(text) - 1) == "~~") {
+        text = trimmed(substr(text, 3, length(text) - 4))
+      }
+      if (text ~ /^\[[ xX]\]/) {
+        text = trimmed(substr(text, 4))
+      }
+      return text
+    }
+    BEGIN {
+      file = ENVIRON["SEED_FILE"]
+      blockers = ENVIRON["SEED_TODO_BLOCKERS"]
+      done_section = ENVIRON["SEED_TODO_DONE"]
+      expected_count = split(ENVIRON["SEED_TODO_SECTIONS"], expected, "\n")
+      for (i = 1; i <= expected_count; i++) position[expected[i]] = i
+      checkbox_count = split(ENVIRON["SEED_TODO_CHECKBOX"], checkbox_list, "\n")
+      for (i = 1; i <= checkbox_count; i++) checkbox[checkbox_list[i]] = 1
+      sections = 0
+    }
+    /^## / {
+      sections++
+      name[sections] = substr($0, 4)
+      line[sections] = NR
+      items[sections] = 0
+      present[name[sections]] = 1
+      next
+    }
+    /^# / { next }
+    sections > 0 && (/^- / || /^\* /) {
+      items[sections]++
+      item_line[sections, items[sections]] = NR
+      item_raw[sections, items[sections]] = $0
+    }
+    END {
+      for (i = 1; i <= expected_count; i++) {
+        if (!(expected[i] in present)) {
+          printf "error\ttodo_section_missing\tMissing TODO section \"%s\" in %s\n", expected[i], file
         }
-        while (match($0, /"[^"]+"/)) {
-          value = substr($0, RSTART + 1, RLENGTH - 2)
-          print value
-          $0 = substr($0, RSTART + RLENGTH)
+      }
+      highest = 0
+      for (s = 1; s <= sections; s++) {
+        if (!(name[s] in position)) continue
+        if (position[name[s]] < highest) {
+          printf "warning\ttodo_section_order\tTODO section \"%s\" in %s:%d is out of order (expected before \"%s\")\n", name[s], file, line[s], expected[highest]
+          continue
+        }
+        highest = position[name[s]]
+      }
+      for (s = 1; s <= sections; s++) {
+        if (name[s] == blockers) {
+          has_none = 0
+          others = 0
+          for (k = 1; k <= items[s]; k++) {
+            if (toupper(item_text(item_raw[s, k])) == "NONE") {
+              has_none = 1
+            } else {
+              others++
+            }
+          }
+          if (has_none && others > 0) {
+            printf "warning\ttodo_blockers_contradiction\tTODO section \"%s\" in %s:%d says NONE but lists %d other item(s)\n", name[s], file, line[s], others
+          }
+        } else if (name[s] in checkbox) {
+          for (k = 1; k <= items[s]; k++) {
+            if (item_raw[s, k] !~ /^[-*] \[[ xX]\] [^[:space:]]/) {
+              printf "warning\ttodo_checkbox\tMalformed TODO item in %s:%d under \"%s\": expected \"- [ ] <task>\"\n", file, item_line[s, k], name[s]
+            }
+          }
+        } else if (name[s] == done_section) {
+          if (done_limit + 0 > 0 && items[s] > done_limit + 0) {
+            printf "warning\ttodo_done_overflow\tTODO section \"%s\" in %s:%d has %d items (limit %d); archive the oldest %d to git history or DECISIONS.md\n", name[s], file, line[s], items[s], done_limit, items[s] - done_limit
+          }
         }
       }
     }
-  ' "$manifest_path"
+  ' "$1"
 }
 
-warnings_as_errors=$(awk '
-  /"warnings_as_errors"[[:space:]]*:/ {
-    line=$0
-    gsub(/[[:space:],]/, "", line)
-    if (line ~ /:true/) {
-      print "true"
-    } else {
-      print "false"
+# section_state prints missing, empty, or ok for "## <heading>" in a file.
+section_state() {
+  SEED_HEADING=$2 awk '
+    BEGIN { target = "## " ENVIRON["SEED_HEADING"]; state = "missing" }
+    in_section && (/^## / || /^# /) { exit }
+    in_section {
+      line = $0
+      gsub(/<!--[^>]*-->/, "", line)
+      if (line ~ /[^[:space:]]/) {
+        state = "ok"
+        exit
+      }
     }
-    exit
-  }
-' "$manifest_path")
-if [ -z "$warnings_as_errors" ]; then
-  warnings_as_errors="false"
-fi
+    $0 == target { in_section = 1; state = "empty" }
+    END { print state }
+  ' "$1"
+}
+
+# list_field_state prints no_section, missing, empty, placeholder, or ok for "- <field>:" under a heading.
+list_field_state() {
+  SEED_HEADING=$2 SEED_FIELD=$3 awk '
+    BEGIN {
+      target = "## " ENVIRON["SEED_HEADING"]
+      prefix = "- " ENVIRON["SEED_FIELD"] ":"
+      state = "no_section"
+    }
+    in_section && (/^## / || /^# /) { exit }
+    in_section && substr($0, 1, length(prefix)) == prefix {
+      value = substr($0, length(prefix) + 1)
+      gsub(/<!--[^>]*-->/, "", value)
+      sub(/#[[:space:]]*seed:placeholder.*$/, "", value)
+      if (value ~ /[^[:space:]]/) {
+        state = "ok"
+      } else if (index($0, "seed:placeholder") > 0) {
+        state = "placeholder"
+      } else {
+        state = "empty"
+      }
+      exit
+    }
+    $0 == target { in_section = 1; state = "missing" }
+    END { print state }
+  ' "$1"
+}
+
+# placeholder_entries prints "<line> <id> <age-days>" for each seed:placeholder marker in a file.
+placeholder_entries() {
+  awk -v today="$today" '
+    function days(date,    y, m, d) {
+      y = substr(date, 1, 4) + 0
+      m = substr(date, 6, 2) + 0
+      d = substr(date, 9, 2) + 0
+      if (m <= 2) {
+        y = y - 1
+        m = m + 12
+      }
+      return 365 * y + int(y / 4) - int(y / 100) + int(y / 400) + int((153 * (m - 3) + 2) / 5) + d
+    }
+    match($0, /seed:placeholder id=[a-z0-9-]*/) {
+      id = substr($0, RSTART + 20, RLENGTH - 20)
+      age = 0
+      if (match($0, /since=[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]/)) {
+        age = days(today) - days(substr($0, RSTART + 6, 10))
+      }
+      printf "%d %s %d\n", NR, id, age
+    }
+  ' "$1"
+}
 
-newline='\
+newline='
 '
 old_ifs=$IFS
 IFS=$newline
 set -f
 
-required_files=$(json_array_values "required_files")
+# Overrides are listed before any finding so they stay visible; invalid ones
+# are reported and ignored.
+if [ -n "$overrides_problem" ]; then
+  errors=$((errors + 1))
+  add_reason "invalid_overrides"
+  printf 'Invalid %s: %s\n' "$overrides_path" "$overrides_problem" >&2
+fi
+for override_entry in $(printf '%s\n' "$overrides_rules" | awk -F '\t' 'index($1, "severities.") == 1 { print substr($1, 12) "\t" $2 }' | LC_ALL=C sort); do
+  printf 'Override: %s findings are %s (%s)\n' "${override_entry%%"$tab"*}" "${override_entry#*"$tab"}" "$overrides_path" >&2
+done
+for override_spec in $(override_values "required_headings"); do
+  printf 'Override: heading "%s" is required in %s (%s)\n' "${override_spec#*::}" "${override_spec%%::*}" "$overrides_path" >&2
+done
+for override_glob in $exempt_files; do
+  printf 'Override: findings for %s are exempt (%s)\n' "$override_glob" "$overrides_path" >&2
+done
+
+# The repo's seed_format_version is compared with the format this script was
+# generated for: older repos get a warning, newer ones are refused.
+repo_format=$(rule_value "seed_format_version")
+script_format=$(sed -n '2s/^# seed:script .* format=\([^ ]*\) .*$/\1/p' "$repo_root/.seed/seed-test.sh")
+if [ -n "$repo_format" ] && [ -n "$script_format" ]; then
+  format_order=$(awk -v a="$repo_format" -v b="$script_format" 'BEGIN {
+    na = split(a, x, ".")
+    nb = split(b, y, ".")
+    n = (na > nb) ? na : nb
+    for (i = 1; i <= n; i++) {
+      if (x[i] + 0 < y[i] + 0) { print -1; exit }
+      if (x[i] + 0 > y[i] + 0) { print 1; exit }
+    }
+    print 0
+  }')
+  if [ "$format_order" = "1" ]; then
+    printf 'SEED_STATUS=fail\n'
+    printf 'SEED_ERRORS=1\n'
+    printf 'SEED_WARNINGS=0\n'
+    printf 'SEED_TRIGGER_REASONS=format_unsupported\n'
+    printf 'Seed format %s in %s is newer than this script supports (%s); upgrade seed and run seed refresh-scripts\n' "$repo_format" "$manifest_path" "$script_format" >&2
+    exit 1
+  fi
+  if [ "$format_order" = "-1" ]; then
+    report_finding warning format_outdated "$manifest_path" "Seed format $repo_format in $manifest_path is older than $script_format; run seed migrate"
+  fi
+fi
+
+# The manifest checksum in the rules header catches manifest edits that were
+# never regenerated; it is skipped when no sha256 tool is installed.
+rules_recorded=$(sed -n '1s/^# seed:rules manifest-sha256=//p' "$rules_path")
+rules_actual=$(file_sha256 "$manifest_path")
+if [ -n "$rules_actual" ] && [ "$rules_actual" != "$rules_recorded" ]; then
+  errors=$((errors + 1))
+  add_reason "rules_mismatch"
+  printf 'Seed rules file %s was generated from a different %s; run seed refresh-scripts\n' "$rules_path" "$manifest_path" >&2
+fi
+if [ "$overrides_stale" = "true" ]; then
+  errors=$((errors + 1))
+  add_reason "rules_mismatch"
+  printf 'Seed rules file %s was generated from a different %s; run seed refresh-scripts\n' "$rules_path" "$overrides_path" >&2
+fi
+
+# With integrity.block_validator_edits, pre-commit refuses guarded scripts that
+# differ from the hashes in .seed/lock.json, which the CLI writes as one
+# "<path>": "<sha256>" pair per line. The lock and setting in HEAD are trusted over
+# the ones being committed, so a commit cannot edit a validator and re-record its
+# hash, or switch the check off, at the same time.
+lock_path=".seed/lock.json"
+lock_label=$lock_path
+block_validator_edits=$(rule_value "integrity.block_validator_edits")
+if [ "$pre_commit" = "true" ] && git -C "$repo_root" cat-file -e "HEAD:$rules_path" 2>/dev/null; then
+  committed_block=$(git -C "$repo_root" show "HEAD:$rules_path" | awk -F '\t' '$1 == "integrity.block_validator_edits" { print $2; exit }')
+  if [ "$committed_block" = "true" ]; then
+    block_validator_edits="true"
+  fi
+fi
+if [ "$pre_commit" = "true" ] && [ "$block_validator_edits" = "true" ]; then
+  lock_json=""
+  if git -C "$repo_root" cat-file -e "HEAD:$lock_path" 2>/dev/null; then
+    lock_json=$(git -C "$repo_root" show "HEAD:$lock_path")
+    lock_label="HEAD:$lock_path"
+  elif [ -f "$lock_path" ]; then
+    lock_json=$(cat "$lock_path")
+  fi
+  if [ -z "$lock_json" ]; then
+    errors=$((errors + 1))
+    add_reason "validator_modified"
+    printf 'Seed lock file %s is missing or unreadable; run seed verify --update to record one\n' "$lock_path" >&2
+  else
+    for validator_path in .seed/seed-test.sh .seed/hooks/pre-commit .seed/hooks/pre-push .seed/install-hooks.sh; do
+      if [ ! -f "$validator_path" ]; then
+        continue
+      fi
+      validator_locked=$(printf '%s\n' "$lock_json" | awk -F '"' -v path="$validator_path" '$2 == path { print $4; exit }')
+      validator_actual=$(file_sha256 "$validator_path")
+      if [ -n "$validator_actual" ] && [ "$validator_actual" != "$validator_locked" ]; then
+        errors=$((errors + 1))
+        add_reason "validator_modified"
+        printf 'Generated validator %s does not match %s; restore it, or commit a reviewed edit with git commit --no-verify\n' "$validator_path" "$lock_label" >&2
+      fi
+    done
+  fi
+fi
+
+required_files=$(rule_values "required_files")
 for required_file in $required_files; do
   if [ ! -f "$required_file" ]; then
-    errors=$((errors + 1))
-    add_reason "missing_file"
-    printf 'Missing required Seed artifact: %s\n' "$required_file" >&2
+    report_finding error missing_file "$required_file" "Missing required Seed artifact: $required_file"
   fi
 done
 
-required_headings=$(json_array_values "required_headings")
-heading_aliases=$(json_array_values "heading_aliases")
+# list_rule_files prints the repo paths file rules match against, like the
+# misplaced-content scan but without tracked files deleted from the work tree.
+list_rule_files() {
+  if [ "$source" != "worktree" ]; then
+    printf '%s\n' "$snapshot_files"
+    return 0
+  fi
+  list_scan_files | sed 's#^\./##' | while IFS= read -r rule_file; do
+    if [ -f "$rule_file" ]; then
+      printf '%s\n' "$rule_file"
+    fi
+  done
+}
+
+# check_file_rules reads sorted paths on stdin and applies the file_rules.<name>
+# globs from rules.tsv in name order: require_any needs one matching path, and
+# every path matching forbid but no allow glob is reported. Findings are
+# "<severity><TAB><reason><TAB><file><TAB><message>" lines.
+check_file_rules() {
+  awk -F '\t' "$glob_regex_awk"'
+    FNR == NR {
+      if (index($1, "file_rules.") != 1) next
+      key = substr($1, 12)
+      kind = key
+      sub(/^.*\./, "", kind)
+      name = substr(key, 1, length(key) - length(kind) - 1)
+      if (!(name in known)) {
+        known[name] = 1
+        names[++rule_count] = name
+      }
+      n = ++glob_count[name, kind]
+      glob[name, kind, n] = $2
+      glob_re[name, kind, n] = glob_regex($2)
+      next
+    }
+    $0 != "" { paths[++path_count] = $0 }
+    function first_match(name, kind, path,    g) {
+      for (g = 1; g <= glob_count[name, kind]; g++) if (path ~ glob_re[name, kind, g]) return g
+      return 0
+    }
+    END {
+      for (r = 1; r <= rule_count; r++) {
+        name = names[r]
+        if (glob_count[name, "require_any"] > 0) {
+          found = 0
+          for (p = 1; p <= path_count && !found; p++) found = first_match(name, "require_any", paths[p])
+          if (!found) {
+            wanted = glob[name, "require_any", 1]
+            for (g = 2; g <= glob_count[name, "require_any"]; g++) wanted = wanted " or " glob[name, "require_any", g]
+            printf "error\tmissing_file_match\t\tFile rule \"%s\" needs a file matching %s\n", name, wanted
+          }
+        }
+        for (p = 1; p <= path_count; p++) {
+          g = first_match(name, "forbid", paths[p])
+          if (g == 0 || first_match(name, "allow", paths[p])) continue
+          printf "error\tforbidden_file\t%s\tFile rule \"%s\" forbids %s (matches %s)\n", paths[p], name, paths[p], glob[name, "forbid", g]
+        }
+      }
+    }
+  ' "$rules_path" -
+}
+
+if grep -q '^file_rules\.' "$rules_path"; then
+  for finding in $(list_rule_files | LC_ALL=C sort -u | check_file_rules); do
+    finding_severity=${finding%%"$tab"*}
+    finding_rest=${finding#*"$tab"}
+    finding_reason=${finding_rest%%"$tab"*}
+    finding_rest=${finding_rest#*"$tab"}
+    finding_file=${finding_rest%%"$tab"*}
+    report_finding "$finding_severity" "$finding_reason" "$finding_file" "${finding_rest#*"$tab"}"
+  done
+fi
+
+required_headings=$({ rule_values "required_headings"; override_values "required_headings"; } | awk '!seen[$0]++')
+heading_aliases=$(rule_values "heading_aliases")
 for heading_spec in $required_headings; do
   heading_file=${heading_spec%%::*}
   heading_name=${heading_spec#*::}
@@ -120,31 +826,195 @@ for heading_spec in $required_headings; do
   done
 
   if [ -n "$alias_match" ]; then
-    warnings=$((warnings + 1))
-    add_reason "heading_alias"
-    printf 'Heading alias detected in %s: expected "%s", found "%s"\n' "$heading_file" "$heading_name" "$alias_match" >&2
+    report_finding warning heading_alias "$heading_file" "Heading alias detected in $heading_file: expected \"$heading_name\", found \"$alias_match\""
+    continue
+  fi
+
+  known_headings=""
+  for known_spec in $required_headings; do
+    if [ "${known_spec%%::*}" = "$heading_file" ]; then
+      known_headings="$known_headings${newline}${known_spec#*::}"
+    fi
+  done
+  for known_spec in $heading_aliases; do
+    if [ "${known_spec%%::*}" = "$heading_file" ]; then
+      known_rest=${known_spec#*::}
+      known_headings="$known_headings${newline}${known_rest#*::}"
+    fi
+  done
+
+  near_miss=$(heading_near_miss "$heading_name" "$heading_file" "$known_headings")
+  if [ -n "$near_miss" ]; then
+    report_finding warning heading_near_miss "$heading_file" "Heading near miss in $heading_file: expected \"$heading_name\", found \"$near_miss\" (rename \"## $near_miss\" to \"## $heading_name\")"
   else
-    errors=$((errors + 1))
-    add_reason "missing_heading"
-    printf 'Missing required heading "%s" in %s\n' "$heading_name" "$heading_file" >&2
+    report_finding error missing_heading "$heading_file" "Missing required heading \"$heading_name\" in $heading_file"
   fi
 done
 
-misplaced_signals=$(json_array_values "misplaced_content_signals")
-for markdown_file in $(find . -type f -name '*.md' | sed 's#^\./##'); do
-  case "$markdown_file" in
-    README.md|DECISIONS.md|TODO.md|CONTEXT.md|AGENTS.md) continue ;;
-    .seed/*|skills/*) continue ;;
+non_empty_sections=$(rule_values "non_empty_sections")
+for section_spec in $non_empty_sections; do
+  section_file=${section_spec%%::*}
+  section_name=${section_spec#*::}
+  if [ ! -f "$section_file" ]; then
+    continue
+  fi
+  if [ "$(section_state "$section_file" "$section_name")" = "empty" ]; then
+    report_finding error empty_section "$section_file" "Empty required section \"$section_name\" in $section_file"
+  fi
+done
+
+required_list_fields=$(rule_values "required_list_fields")
+for field_spec in $required_list_fields; do
+  field_file=${field_spec%%::*}
+  field_rest=${field_spec#*::}
+  field_section=${field_rest%%::*}
+  field_name=${field_rest#*::}
+  if [ ! -f "$field_file" ]; then
+    continue
+  fi
+  case "$(list_field_state "$field_file" "$field_section" "$field_name")" in
+    missing)
+      report_finding error empty_list_field "$field_file" "Missing required list field \"- $field_name:\" under \"$field_section\" in $field_file"
+      ;;
+    empty)
+      report_finding error empty_list_field "$field_file" "Required list field \"- $field_name:\" has no value under \"$field_section\" in $field_file"
+      ;;
+  esac
+done
+
+for seed_doc in README.md DECISIONS.md TODO.md CONTEXT.md AGENTS.md; do
+  if [ ! -f "$seed_doc" ]; then
+    continue
+  fi
+  for entry in $(placeholder_entries "$seed_doc"); do
+    placeholder_line=${entry%% *}
+    entry_rest=${entry#* }
+    placeholder_id=${entry_rest%% *}
+    placeholder_age=${entry_rest#* }
+    if [ "$pre_commit" = "true" ] && [ "$placeholder_block_commit" = "true" ]; then
+      report_finding error unresolved_placeholder "$seed_doc" "Unresolved Seed placeholder \"$placeholder_id\" in $seed_doc:$placeholder_line must be replaced before commit"
+    elif [ "$placeholder_max_age" -gt 0 ] && [ "$placeholder_age" -gt "$placeholder_max_age" ]; then
+      report_finding error unresolved_placeholder "$seed_doc" "Unresolved Seed placeholder \"$placeholder_id\" in $seed_doc:$placeholder_line is $placeholder_age days old (limit $placeholder_max_age days)"
+    else
+      report_finding info unresolved_placeholder "$seed_doc" "Note: Seed placeholder \"$placeholder_id\" in $seed_doc:$placeholder_line is $placeholder_age days old (limit $placeholder_max_age days)"
+    fi
+  done
+done
+
+decisions_file=$(rule_value "decisions_file")
+if [ -n "$decisions_file" ] && [ -f "$decisions_file" ]; then
+  report_findings "$decisions_file" "$(check_decisions "$decisions_file")"
+fi
+
+todo_file=$(rule_value "todo_file")
+if [ -n "$todo_file" ] && [ -f "$todo_file" ]; then
+  todo_sections=$(rule_values "todo_sections")
+  todo_checkbox_sections=$(rule_values "todo_checkbox_sections")
+  todo_blockers_section=$(rule_value "todo_blockers_section")
+  todo_done_section=$(rule_value "todo_done_section")
+  todo_done_limit=$(rule_value "todo_done_limit")
+  report_findings "$todo_file" "$(check_todo "$todo_file")"
+fi
+
+# scan_candidate reports whether a scanned path exists and is not owned by Seed.
+scan_candidate() {
+  if [ ! -f "$1" ]; then
+    return 1
+  fi
+  case "$1" in
+    README.md|DECISIONS.md|TODO.md|CONTEXT.md|AGENTS.md) return 1 ;;
+    .seed/*|skills/*) return 1 ;;
   esac
+  return 0
+}
+
+# check_phrases scores a file against "DOC.md::phrase" signals. Each line that
+# contains a phrase (case-insensitive) adds one point to that doc; in comments
+# mode only lines starting with a comment marker count. Docs that reach the
+# minimum score get one warning naming the first match.
+check_phrases() {
+  SEED_PHRASES=$misplaced_phrases awk -v file="$1" -v mode="$2" -v min_score="$phrase_min_score" '
+    BEGIN {
+      count = split(ENVIRON["SEED_PHRASES"], specs, "\n")
+      for (i = 1; i <= count; i++) {
+        sep = index(specs[i], "::")
+        if (sep == 0 || sep + 2 > length(specs[i])) continue
+        doc[i] = substr(specs[i], 1, sep - 1)
+        phrase[i] = substr(specs[i], sep + 2)
+        needle[i] = tolower(phrase[i])
+        if (!(doc[i] in known_doc)) {
+          known_doc[doc[i]] = 1
+          docs[++doc_count] = doc[i]
+        }
+      }
+      if (min_score + 0 < 1) min_score = 1
+    }
+    {
+      text = $0
+      if (mode == "comments") {
+        if (!match(text, /^[ \t]*(\/\/|\/\*|\*|#|--|;)/)) next
+        text = substr(text, RSTART + RLENGTH)
+      }
+      text = tolower(text)
+      for (i = 1; i <= count; i++) {
+        if (!(i in doc) || index(text, needle[i]) == 0 || (doc[i], NR) in line_hit) continue
+        line_hit[doc[i], NR] = 1
+        score[doc[i]]++
+        if (!(doc[i] in first_phrase)) {
+          first_phrase[doc[i]] = phrase[i]
+          first_line[doc[i]] = NR
+        }
+      }
+    }
+    END {
+      for (d = 1; d <= doc_count; d++) {
+        name = docs[d]
+        if (score[name] + 0 < min_score + 0) continue
+        printf "warning\tmisplaced_phrase\tPossible misplaced Seed content in %s (score %d): \"%s\" at line %d; consider moving it to %s\n", file, score[name], first_phrase[name], first_line[name], name
+      }
+    }
+  ' "$1"
+}
+
+misplaced_signals=$(rule_values "misplaced_content_signals")
+misplaced_phrases=$(rule_values "misplaced_content_phrases.signals")
+phrase_min_score=$(rule_value "misplaced_content_phrases.min_score")
+scan_include=$(rule_values "misplaced_content_scope.include")
+if [ -z "$scan_include" ]; then
+  scan_include='**/*.md'
+fi
+scan_comment_include=$(rule_values "misplaced_content_scope.comment_include")
+scan_exclude=$(rule_values "misplaced_content_scope.exclude")
+scan_files=$(list_scan_files | LC_ALL=C sort -u)
+text_files=$(printf '%s\n' "$scan_files" | scan_scope_filter "$scan_include")
+comment_files=$(printf '%s\n' "$scan_files" | scan_scope_filter "$scan_comment_include")
 
+for scan_file in $text_files; do
+  if ! scan_candidate "$scan_file"; then
+    continue
+  fi
   for signal in $misplaced_signals; do
-    if grep -Fqx "## $signal" "$markdown_file"; then
-      warnings=$((warnings + 1))
-      add_reason "misplaced_content"
-      printf 'Potential misplaced Seed content in %s: heading "%s"\n' "$markdown_file" "$signal" >&2
+    if grep -Fqx "## $signal" "$scan_file"; then
+      report_finding warning misplaced_content "$scan_file" "Potential misplaced Seed content in $scan_file: heading \"$signal\""
       break
     fi
   done
+  if [ -n "$misplaced_phrases" ]; then
+    report_findings "$scan_file" "$(check_phrases "$scan_file" text)"
+  fi
+done
+
+# Files matched by both scopes are read as text only.
+for scan_file in $comment_files; do
+  case "$newline$text_files$newline" in
+    *"$newline$scan_file$newline"*) continue ;;
+  esac
+  if ! scan_candidate "$scan_file"; then
+    continue
+  fi
+  if [ -n "$misplaced_phrases" ]; then
+    report_findings "$scan_file" "$(check_phrases "$scan_file" comments)"
+  fi
 done
 
 set +f
@@ -186,8 +1056,27 @@ set -eu
 repo_root=$(CDPATH= cd -- "$(dirname -- "$0")/../.." && pwd)
 cd "$repo_root"
 
+# Run the hook that was active before Seed first, if install-hooks.sh chained one.
+prior_dir=$(git config --get seed.priorHooksDir || true)
+if [ -n "$prior_dir" ] && [ -x "$prior_dir/pre-commit" ]; then
+  "$prior_dir/pre-commit" "$@" || exit $?
+fi
+
+# Validate staged content by default; set SEED_HOOK_SOURCE=worktree or
+# git config seed.hookSource worktree to check the working tree instead.
+hook_source=${SEED_HOOK_SOURCE:-$(git config --get seed.hookSource || true)}
+case "$hook_source" in
+  ""|index) source_flag="--staged" ;;
+  worktree) source_flag="--worktree" ;;
+  *)
+    printf 'Unknown seed.hookSource: %s (expected index|worktree)\n' "$hook_source" >&2
+    exit 1
+    ;;
+esac
+printf 'SEED_HOOK_SOURCE=%s\n' "${hook_source:-index}" >&2
+
 set +e
-output=$(./.seed/seed-test.sh 2>&1)
+output=$(./.seed/seed-test.sh --pre-commit "$source_flag" 2>&1)
 code=$?
 set -e
 
@@ -214,12 +1103,97 @@ case "$code" in
 esac
 `
 
+const guardedPrePushHookScript = `#!/usr/bin/env sh
+set -eu
+
+repo_root=$(CDPATH= cd -- "$(dirname -- "$0")/../.." && pwd)
+cd "$repo_root"
+
+# git passes "<local ref> <local sha> <remote ref> <remote sha>" per pushed ref
+# on stdin. Every commit new to the remote is validated as committed, so
+# commits made with --no-verify cannot reach the remote unchecked.
+remote_name=${1:-origin}
+push_refs=$(cat)
+
+# Run the hook that was active before Seed first, if install-hooks.sh chained one.
+prior_dir=$(git config --get seed.priorHooksDir || true)
+if [ -n "$prior_dir" ] && [ -x "$prior_dir/pre-push" ]; then
+  printf '%s\n' "$push_refs" | "$prior_dir/pre-push" "$@" || exit $?
+fi
+
+is_zero_sha() {
+  case "$1" in
+    *[!0]*) return 1 ;;
+    *) return 0 ;;
+  esac
+}
+
+commits=""
+while read -r local_ref local_sha remote_ref remote_sha; do
+  if is_zero_sha "$local_sha"; then
+    continue
+  fi
+  if ! is_zero_sha "$remote_sha" && git cat-file -e "$remote_sha^{commit}" 2>/dev/null; then
+    commits="$commits $(git rev-list --reverse "$remote_sha..$local_sha")"
+  else
+    commits="$commits $(git rev-list --reverse "$local_sha" --not --remotes="$remote_name")"
+  fi
+done <<EOF
+$push_refs
+EOF
+
+checked=0
+seen=" "
+for commit in $commits; do
+  case "$seen" in
+    *" $commit "*) continue ;;
+  esac
+  seen="$seen$commit "
+  checked=$((checked + 1))
+
+  set +e
+  output=$(./.seed/seed-test.sh --pre-commit --rev "$commit" 2>&1)
+  code=$?
+  set -e
+
+  case "$code" in
+    0) ;;
+    2)
+      printf '%s\n' "$output"
+      printf 'SEED_PUSH_WARNING_COMMIT=%s\n' "$commit" >&2
+      ;;
+    *)
+      printf '%s\n' "$output"
+      printf 'SEED_PUSH_BLOCKED_COMMIT=%s\n' "$commit" >&2
+      printf 'SEED_HOOK_DECISION=blocked\n' >&2
+      printf 'Seed contract fails at %s; fix it in a new commit or rewrite that commit before pushing.\n' \
+        "$(git log -1 --format='%h %s' "$commit")" >&2
+      exit 1
+      ;;
+  esac
+done
+
+printf 'SEED_PUSH_COMMITS_CHECKED=%s\n' "$checked" >&2
+exit 0
+`
+
 const guardedInstallHooksScript = `#!/usr/bin/env sh
 set -eu
 
 repo_root=$(CDPATH= cd -- "$(dirname -- "$0")/.." && pwd)
 cd "$repo_root"
 
+force="false"
+for arg in "$@"; do
+  case "$arg" in
+    --force) force="true" ;;
+    *)
+      printf 'Unknown install-hooks.sh argument: %s\n' "$arg" >&2
+      exit 64
+      ;;
+  esac
+done
+
 if ! git rev-parse --is-inside-work-tree >/dev/null 2>&1; then
   printf 'Error: not inside a git repository. Run git init (or clone) first.\n' >&2
   exit 1
@@ -230,17 +1204,102 @@ if [ ! -f ".seed/hooks/pre-commit" ]; then
   exit 1
 fi
 
+if [ ! -f ".seed/hooks/pre-push" ]; then
+  printf 'Error: missing .seed/hooks/pre-push\n' >&2
+  exit 1
+fi
+
 if [ ! -f ".seed/seed-test.sh" ]; then
   printf 'Error: missing .seed/seed-test.sh\n' >&2
   exit 1
 fi
 
-chmod +x .seed/hooks/pre-commit .seed/seed-test.sh
+chmod +x .seed/hooks/pre-commit .seed/hooks/pre-push .seed/seed-test.sh
+
+seed_hooks=".seed/hooks"
+# Hooks git may run; existing ones keep running after Seed takes over core.hooksPath.
+git_hook_names="applypatch-msg pre-applypatch post-applypatch pre-commit pre-merge-commit
+prepare-commit-msg commit-msg post-commit pre-rebase post-checkout post-merge pre-push
+pre-receive update proc-receive post-receive post-update reference-transaction
+push-to-checkout pre-auto-gc post-rewrite sendemail-validate post-index-change"
+
+same_dir() {
+  [ -d "$1" ] && [ -d "$2" ] && [ "$(cd "$1" && pwd -P)" = "$(cd "$2" && pwd -P)" ]
+}
+
+hooks_in() {
+  for name in $git_hook_names; do
+    if [ -f "$1/$name" ] && [ -x "$1/$name" ]; then
+      printf '%s\n' "$name"
+    fi
+  done
+}
+
+current=$(git config --get core.hooksPath || true)
+if [ -n "$current" ] && same_dir "$current" "$seed_hooks"; then
+  # Already installed: keep the prior hooks recorded by the first install.
+  prior_dir=$(git config --local --get seed.priorHooksDir || true)
+else
+  prior_dir=${current:-$(git rev-parse --git-path hooks)}
+  existing=$(hooks_in "$prior_dir" | tr '\n' ' ' | sed 's/ $//')
+  if [ -n "$current" ] || [ -n "$existing" ]; then
+    if [ "$force" != "true" ]; then
+      printf 'Error: installing Seed hooks would replace the current git hooks.\n' >&2
+      if [ -n "$current" ]; then
+        printf '  core.hooksPath=%s\n' "$current" >&2
+      fi
+      if [ -n "$existing" ]; then
+        printf '  existing hooks in %s: %s\n' "$prior_dir" "$existing" >&2
+      fi
+      printf 'Run ./.seed/install-hooks.sh --force to chain them from .seed/hooks and record the previous setting.\n' >&2
+      exit 1
+    fi
+  fi
+
+  # Record the previous setting so it can be restored later.
+  if [ -n "$current" ]; then
+    git config --local seed.priorHooksPath "$current"
+  else
+    git config --local --unset seed.priorHooksPath || true
+  fi
+  if [ -n "$existing" ]; then
+    git config --local seed.priorHooksDir "$prior_dir"
+  else
+    git config --local --unset seed.priorHooksDir || true
+    prior_dir=""
+  fi
+fi
+
+chained=""
+if [ -n "$prior_dir" ]; then
+  for name in $(hooks_in "$prior_dir"); do
+    chained="$chained $name"
+    # Seed's own pre-commit and pre-push run the prior hook before validating.
+    case "$name" in
+      pre-commit|pre-push) continue ;;
+    esac
+    wrapper="$seed_hooks/$name"
+    if [ -f "$wrapper" ] && ! grep -q 'seed:chained-hook' "$wrapper" && [ "$force" != "true" ]; then
+      printf 'Error: %s exists and is not a Seed wrapper; rerun with --force to replace it.\n' "$wrapper" >&2
+      exit 1
+    fi
+    cat >"$wrapper" <<EOF
+#!/usr/bin/env sh
+# seed:chained-hook generated by .seed/install-hooks.sh; runs the $name hook that was active before Seed.
+prior_dir=\$(git config --get seed.priorHooksDir || true)
+if [ -n "\$prior_dir" ] && [ -x "\$prior_dir/$name" ]; then
+  exec "\$prior_dir/$name" "\$@"
+fi
+exit 0
+EOF
+    chmod +x "$wrapper"
+  done
+fi
 
-git config core.hooksPath .seed/hooks
+git config core.hooksPath "$seed_hooks"
 
 configured=$(git config --local --get core.hooksPath || true)
-if [ "$configured" != ".seed/hooks" ]; then
+if [ "$configured" != "$seed_hooks" ]; then
   printf 'Error: failed to configure core.hooksPath\n' >&2
   exit 1
 fi
@@ -248,4 +1307,8 @@ fi
 printf 'Seed hooks installed.\n'
 printf 'core.hooksPath=%s\n' "$configured"
 printf 'pre-commit now runs ./.seed/seed-test.sh\n'
+printf 'pre-push now validates every pushed commit\n'
+if [ -n "$chained" ]; then
+  printf 'Chained prior hooks from %s:%s\n' "$prior_dir" "$chained"
+fi
 `
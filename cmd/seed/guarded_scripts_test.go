@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestShellGoParity runs seed-test.sh under every local POSIX shell and the Go
+// rules over the same mutated repos; status, counts and reasons must agree.
+func TestShellGoParity(t *testing.T) {
+	requireGit(t)
+	shells := parityShells()
+	if len(shells) == 0 {
+		t.Skip("no POSIX shell found")
+	}
+
+	rename := func(file, from, to string) func(t *testing.T, root string) {
+		return func(t *testing.T, root string) {
+			mustRewriteFile(t, filepath.Join(root, file), func(content string) string {
+				return strings.Replace(content, "## "+from+"\n", "## "+to+"\n", 1)
+			})
+		}
+	}
+	remove := func(file string) func(t *testing.T, root string) {
+		return func(t *testing.T, root string) {
+			if err := os.Remove(filepath.Join(root, file)); err != nil {
+				t.Fatalf("remove %s: %v", file, err)
+			}
+		}
+	}
+	addDoc := func(file, content string) func(t *testing.T, root string) {
+		return func(t *testing.T, root string) {
+			if err := writeFile(filepath.Join(root, filepath.FromSlash(file)), content, 0o644); err != nil {
+				t.Fatalf("write %s: %v", file, err)
+			}
+		}
+	}
+
+	scope := func(include, exclude string) func(t *testing.T, root string) {
+		return func(t *testing.T, root string) {
+			manifestPath := filepath.Join(root, ".seed", "manifest.json")
+			mustRewriteFile(t, manifestPath, func(content string) string {
+				content = strings.Replace(content, `"**/*.md"`, `"**/*.md", "`+include+`"`, 1)
+				return strings.Replace(content, `"exclude": []`, `"exclude": ["`+exclude+`"]`, 1)
+			})
+			if err := writeRulesFile(root, []byte(mustReadFile(t, manifestPath))); err != nil {
+				t.Fatalf("regenerate rules.tsv: %v", err)
+			}
+		}
+	}
+
+	// editOverrides writes .seed/overrides.json and leaves rules.tsv stale;
+	// overrides also regenerates rules.tsv, as seed refresh-scripts would.
+	editOverrides := func(content string) func(t *testing.T, root string) {
+		return func(t *testing.T, root string) {
+			if err := writeFile(filepath.Join(root, ".seed", "overrides.json"), content, 0o644); err != nil {
+				t.Fatalf("write overrides: %v", err)
+			}
+		}
+	}
+	overrides := func(content string) func(t *testing.T, root string) {
+		return func(t *testing.T, root string) {
+			editOverrides(content)(t, root)
+			manifestPath := filepath.Join(root, ".seed", "manifest.json")
+			if err := writeRulesFile(root, []byte(mustReadFile(t, manifestPath))); err != nil {
+				t.Fatalf("regenerate rules.tsv: %v", err)
+			}
+		}
+	}
+	fileRule := func(name, rule string) func(t *testing.T, root string) {
+		return func(t *testing.T, root string) {
+			manifestPath := filepath.Join(root, ".seed", "manifest.json")
+			mustRewriteFile(t, manifestPath, func(manifest string) string {
+				return strings.Replace(manifest, `"file_rules": {`, `"file_rules": {"`+name+`": `+rule+`,`, 1)
+			})
+			if err := writeRulesFile(root, []byte(mustReadFile(t, manifestPath))); err != nil {
+				t.Fatalf("regenerate rules.tsv: %v", err)
+			}
+		}
+	}
+	severities := func(content string) func(t *testing.T, root string) {
+		return func(t *testing.T, root string) {
+			manifestPath := filepath.Join(root, ".seed", "manifest.json")
+			mustRewriteFile(t, manifestPath, func(manifest string) string {
+				return strings.Replace(manifest, `"active_profile"`, `"rule_severities": `+content+`,
+  "active_profile"`, 1)
+			})
+			if err := writeRulesFile(root, []byte(mustReadFile(t, manifestPath))); err != nil {
+				t.Fatalf("regenerate rules.tsv: %v", err)
+			}
+		}
+	}
+
+	mutations := []struct {
+		name    string
+		reason  string
+		mutates []func(t *testing.T, root string)
+	}{
+		{name: "unchanged", reason: "none"},
+		{name: "deleted readme", reason: "missing_file", mutates: []func(*testing.T, string){remove("README.md")}},
+		{name: "deleted context and todo", reason: "missing_file", mutates: []func(*testing.T, string){remove("CONTEXT.md"), remove("TODO.md")}},
+		{name: "deleted agents", reason: "missing_file", mutates: []func(*testing.T, string){remove("AGENTS.md")}},
+		{name: "near-miss heading", reason: "heading_near_miss", mutates: []func(*testing.T, string){rename("README.md", "Quick Start", "Quik Start")}},
+		{name: "renamed heading", reason: "missing_heading", mutates: []func(*testing.T, string){rename("CONTEXT.md", "Key Files", "Repository Map")}},
+		{name: "alias heading", reason: "heading_alias", mutates: []func(*testing.T, string){rename("README.md", "Quick Start", "Getting Started")}},
+		{name: "alias and near miss", reason: "heading_alias", mutates: []func(*testing.T, string){
+			rename("AGENTS.md", "POC Guardrails", "Guardrails"),
+			rename("CONTEXT.md", "Upgrade Triggers", "Upgrade Trigers"),
+		}},
+		{name: "misplaced heading in docs", reason: "misplaced_content", mutates: []func(*testing.T, string){
+			addDoc("docs/guide.md", "# Guide\n\n## Current Status\n\nShipping.\n"),
+		}},
+		{name: "misplaced headings in nested docs", reason: "misplaced_content", mutates: []func(*testing.T, string){
+			addDoc("docs/notes.md", "# Notes\n\n## Known Limitations\n\n- Slow.\n"),
+			addDoc("docs/design/plan.md", "# Plan\n\n## Upgrade Triggers\n\n- Scale.\n\n## POC Philosophy\n\nSmall.\n"),
+		}},
+		{name: "misplaced heading and deleted file", reason: "misplaced_content", mutates: []func(*testing.T, string){
+			addDoc("docs/guide.md", "# Guide\n\n## Quick Start\n\nRun it.\n"),
+			remove("DECISIONS.md"),
+		}},
+		{name: "phrase signals in docs", reason: "misplaced_phrase", mutates: []func(*testing.T, string){
+			addDoc("docs/meeting.md", "Notes\n\nWe decided to use Postgres.\nLater WE CHOSE sqlite.\nKnown issue: slow start.\n"),
+		}},
+		{name: "phrase signals in code comments", reason: "misplaced_phrase", mutates: []func(*testing.T, string){
+			addDoc("src/server.go", "package main\n\n// Status: blocked by the auth rewrite\n  /* waiting on infra */\nfunc main() { _ = \"blocked by\" }\n"),
+			addDoc("scripts/deploy.py", "# known issue: retries\nprint('known issue')\n"),
+		}},
+		{name: "third-party and build docs skipped", reason: "none", mutates: []func(*testing.T, string){
+			addDoc("node_modules/pkg/README.md", "# Pkg\n\n## Quick Start\n\nnpm i.\n"),
+			addDoc("vendor/lib/docs/README.md", "# Lib\n\n## Known Limitations\n\n- None.\n"),
+			addDoc("build/out.md", "# Out\n\n## Current Status\n\nBuilt.\n"),
+		}},
+		{name: "gitignored docs skipped", reason: "none", mutates: []func(*testing.T, string){
+			addDoc(".gitignore", "generated/\n"),
+			addDoc("generated/report.md", "# Report\n\n## Current Status\n\nGenerated.\n"),
+		}},
+		{name: "manifest scan scope", reason: "misplaced_content", mutates: []func(*testing.T, string){
+			scope("docs/*.txt", "docs/archive/**"),
+			addDoc("docs/archive/old.md", "# Old\n\n## Current Status\n\nArchived.\n"),
+			addDoc("docs/notes.txt", "Notes\n\n## Known Limitations\n\n- Slow.\n"),
+		}},
+		{name: "manifest severity raised", reason: "heading_alias", mutates: []func(*testing.T, string){
+			severities(`{"heading_alias": "error"}`),
+			rename("README.md", "Quick Start", "Getting Started"),
+		}},
+		{name: "override severities", reason: "missing_heading", mutates: []func(*testing.T, string){
+			overrides(`{"severities": {"missing_heading": "warning", "misplaced_content": "off"}}`),
+			rename("CONTEXT.md", "Key Files", "Repository Map"),
+			addDoc("docs/guide.md", "# Guide\n\n## Current Status\n\nShipping.\n"),
+		}},
+		{name: "override beats manifest severity", reason: "none", mutates: []func(*testing.T, string){
+			severities(`{"heading_alias": "error"}`),
+			overrides(`{"severities": {"heading_alias": "info"}}`),
+			rename("README.md", "Quick Start", "Getting Started"),
+		}},
+		{name: "override heading and exemption", reason: "missing_heading", mutates: []func(*testing.T, string){
+			overrides(`{"required_headings": ["README.md::Architecture"], "exempt_files": ["docs/legacy/**"]}`),
+			addDoc("docs/legacy/old.md", "# Old\n\n## Quick Start\n\nRun it.\n"),
+		}},
+		{name: "override heading close to a known heading", reason: "missing_heading", mutates: []func(*testing.T, string){
+			overrides(`{"required_headings": ["README.md::Quick Starts"]}`),
+		}},
+		{name: "forbidden root plans", reason: "forbidden_file", mutates: []func(*testing.T, string){
+			addDoc("ROADMAP.md", "# Roadmap\n"),
+			addDoc("docs/PLAN.md", "# Nested plans are allowed\n"),
+		}},
+		{name: "stray seed file", reason: "forbidden_file", mutates: []func(*testing.T, string){
+			addDoc(".seed/notes.txt", "scratch\n"),
+			addDoc(".seed/hooks/post-merge", "#!/bin/sh\n"),
+		}},
+		{name: "require any unmet", reason: "missing_file_match", mutates: []func(*testing.T, string){
+			fileRule("adr", `{"require_any": ["docs/adr/**/*.md", "ADR.md"]}`),
+			addDoc("docs/adr.txt", "not a match\n"),
+		}},
+		{name: "require any met by nested glob", reason: "none", mutates: []func(*testing.T, string){
+			fileRule("adr", `{"require_any": ["docs/adr/**/*.md", "ADR.md"]}`),
+			addDoc("docs/adr/2026/0001-use-go.md", "# Use Go\n"),
+		}},
+		{name: "forbid with allow", reason: "forbidden_file", mutates: []func(*testing.T, string){
+			fileRule("flat-docs", `{"forbid": ["docs/**"], "allow": ["docs/*.md"]}`),
+			addDoc("docs/guide.md", "# Guide\n"),
+			addDoc("docs/deep/guide.md", "# Deep\n"),
+		}},
+		{name: "forbidden file exempted", reason: "none", mutates: []func(*testing.T, string){
+			overrides(`{"exempt_files": ["ROADMAP.md"]}`),
+			addDoc("ROADMAP.md", "# Roadmap\n"),
+		}},
+		{name: "overrides edited after refresh", reason: "rules_mismatch", mutates: []func(*testing.T, string){
+			overrides(`{"severities": {"heading_alias": "info"}}`),
+			editOverrides(`{"exempt_files": ["README.md"]}`),
+			rename("README.md", "Quick Start", "Getting Started"),
+		}},
+		{name: "overrides removed after refresh", reason: "rules_mismatch", mutates: []func(*testing.T, string){
+			overrides(`{"exempt_files": ["README.md"]}`),
+			remove(".seed/overrides.json"),
+			rename("README.md", "Quick Start", "Getting Started"),
+		}},
+		{name: "overrides added without refresh", reason: "rules_mismatch", mutates: []func(*testing.T, string){
+			editOverrides(`{"exempt_files": ["README.md"]}`),
+			rename("README.md", "Quick Start", "Getting Started"),
+		}},
+		{name: "overrides unknown field", reason: "invalid_overrides", mutates: []func(*testing.T, string){
+			overrides(`{"exempt": ["README.md"]}`),
+		}},
+		{name: "overrides integrity reason", reason: "invalid_overrides", mutates: []func(*testing.T, string){
+			overrides(`{"severities": {"rules_mismatch": "off"}}`),
+		}},
+		{name: "overrides unknown level", reason: "invalid_overrides", mutates: []func(*testing.T, string){
+			overrides(`{"severities": {"missing_heading": "loud"}}`),
+		}},
+		{name: "overrides heading spec", reason: "invalid_overrides", mutates: []func(*testing.T, string){
+			overrides(`{"required_headings": ["README.md"]}`),
+		}},
+		{name: "overrides duplicate glob", reason: "invalid_overrides", mutates: []func(*testing.T, string){
+			overrides(`{"exempt_files": ["docs/**", "docs/**"]}`),
+		}},
+		{name: "rules file missing", reason: "missing_rules", mutates: []func(*testing.T, string){
+			remove(".seed/rules.tsv"),
+		}},
+		{name: "overrides malformed", reason: "invalid_overrides", mutates: []func(*testing.T, string){
+			overrides(`{"exempt_files": ["docs/**"]`),
+			rename("README.md", "Quick Start", "Getting Started"),
+		}},
+	}
+
+	manifest := mustLoadManifest(t)
+	for _, warningsAsErrors := range []bool{false, true} {
+		for _, mutation := range mutations {
+			name := fmt.Sprintf("%s/warnings_as_errors=%t", mutation.name, warningsAsErrors)
+			t.Run(name, func(t *testing.T) {
+				target := filepath.Join(t.TempDir(), "guarded")
+				mustScaffoldGitRepo(t, target, profileGuarded, manifest)
+				if warningsAsErrors {
+					manifestPath := filepath.Join(target, ".seed", "manifest.json")
+					mustRewriteFile(t, manifestPath, func(content string) string {
+						return strings.Replace(content, `"warnings_as_errors": false`, `"warnings_as_errors": true`, 1)
+					})
+					if err := writeRulesFile(target, []byte(mustReadFile(t, manifestPath))); err != nil {
+						t.Fatalf("regenerate rules.tsv: %v", err)
+					}
+				}
+				for _, mutate := range mutation.mutates {
+					mutate(t, target)
+				}
+
+				rules, _, err := loadRepoRules(worktreeSource{root: target}, profileGuarded)
+				if err != nil {
+					t.Fatalf("load repo rules: %v", err)
+				}
+				if rules.WarningsAsErrors != warningsAsErrors {
+					t.Fatalf("manifest warnings_as_errors=%t, want %t", rules.WarningsAsErrors, warningsAsErrors)
+				}
+				report := validateContract(worktreeSource{root: target}, rules, contractOptions{today: time.Now()})
+				status, reasons, code := report.outcome(rules.WarningsAsErrors)
+				var goOut bytes.Buffer
+				printSeedStatus(&goOut, status, report, reasons)
+				want := parseSeedStatus(goOut.String())
+				var goFindings strings.Builder
+				for _, finding := range report.Findings {
+					goFindings.WriteString(finding.Message + "\n")
+				}
+				goMessages := sortedLines(goFindings.String())
+				if !containsString(strings.Split(want["SEED_TRIGGER_REASONS"], ","), mutation.reason) {
+					t.Fatalf("mutation did not trigger %s: %v", mutation.reason, want)
+				}
+
+				for _, shell := range shells {
+					cmd := exec.Command(shell[0], append(shell[1:], filepath.Join(target, ".seed", "seed-test.sh"))...)
+					var stdout, stderr bytes.Buffer
+					cmd.Stdout = &stdout
+					cmd.Stderr = &stderr
+					shellCode := 0
+					if err := cmd.Run(); err != nil {
+						var exitErr *exec.ExitError
+						if !errors.As(err, &exitErr) {
+							t.Fatalf("%s: %v", strings.Join(shell, " "), err)
+						}
+						shellCode = exitErr.ExitCode()
+					}
+					got := parseSeedStatus(stdout.String())
+					if shellCode != code || fmt.Sprint(got) != fmt.Sprint(want) {
+						t.Fatalf("%s disagrees with Go rules:\nshell exit=%d %v\ngo    exit=%d %v\nshell stderr:\n%s\ngo findings: %+v",
+							strings.Join(shell, " "), shellCode, got, code, want, stderr.String(), report.Findings)
+					}
+					// The findings themselves must match too, not only their counts.
+					if shellMessages := sortedLines(stderr.String()); !reflect.DeepEqual(shellMessages, goMessages) {
+						t.Fatalf("%s reports different findings:\nshell: %q\ngo:    %q", strings.Join(shell, " "), shellMessages, goMessages)
+					}
+				}
+			})
+		}
+	}
+}
+
+// parityShells lists the POSIX shells available to run seed-test.sh under.
+func parityShells() [][]string {
+	candidates := [][]string{{"dash"}, {"bash", "--posix"}, {"busybox", "sh"}}
+	shells := make([][]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err == nil {
+			shells = append(shells, candidate)
+		}
+	}
+	return shells
+}
+
+// sortedLines splits output into sorted non-empty lines so finding order does not matter.
+func sortedLines(output string) []string {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(output, "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
@@ -0,0 +1,379 @@
+package main
+
+// Hooks command makes guarded hook state visible and reversible without hand-editing git config.
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const commandHooks = "hooks"
+
+const (
+	hooksStatus    = "status"
+	hooksInstall   = "install"
+	hooksUninstall = "uninstall"
+	hooksRepair    = "repair"
+)
+
+const (
+	scriptCurrent  = "current"
+	scriptOutdated = "outdated"
+	scriptMissing  = "missing"
+)
+
+// seedHooksPath is the core.hooksPath value written by install-hooks.sh.
+const seedHooksPath = ".seed/hooks"
+
+// chainedHookMarker tags pass-through wrappers generated by install-hooks.sh.
+const chainedHookMarker = "seed:chained-hook"
+
+type hooksOptions struct {
+	action     string
+	repoPath   string
+	jsonOutput bool
+	force      bool
+}
+
+type hooksReport struct {
+	RepoPath       string            `json:"repo_path"`
+	HooksPath      string            `json:"hooks_path"`
+	Installed      bool              `json:"installed"`
+	Healthy        bool              `json:"healthy"`
+	PriorHooksPath string            `json:"prior_hooks_path,omitempty"`
+	PriorHooksDir  string            `json:"prior_hooks_dir,omitempty"`
+	Scripts        []hookScriptState `json:"scripts"`
+	ChainedHooks   []string          `json:"chained_hooks"`
+	Actions        []string          `json:"actions,omitempty"`
+}
+
+type hookScriptState struct {
+	Path  string `json:"path"`
+	State string `json:"state"`
+	// Version is the Seed CLI version stamped in the script header, if any.
+	Version    string `json:"version,omitempty"`
+	Detail     string `json:"detail,omitempty"`
+	Executable bool   `json:"executable"`
+}
+
+func parseHooksArgs(opts options, args []string) (options, error) {
+	if len(args) == 0 {
+		return opts, errors.New("missing hooks subcommand (expected status|install|uninstall|repair)")
+	}
+	switch args[0] {
+	case hooksStatus, hooksInstall, hooksUninstall, hooksRepair:
+		opts.hooks.action = args[0]
+	case "-h", "--help":
+		opts.showHelp = true
+		return opts, nil
+	default:
+		return opts, fmt.Errorf("unknown hooks subcommand: %s", args[0])
+	}
+
+	args = args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		opts.hooks.repoPath = args[0]
+		args = args[1:]
+	}
+	for _, arg := range args {
+		switch arg {
+		case "--json":
+			opts.hooks.jsonOutput = true
+		case "--force":
+			if opts.hooks.action != hooksInstall {
+				return opts, errors.New("--force only applies to hooks install")
+			}
+			opts.hooks.force = true
+		case "-h", "--help":
+			opts.showHelp = true
+		default:
+			return opts, fmt.Errorf("unknown argument: %s", arg)
+		}
+	}
+	return opts, nil
+}
+
+func printHooksUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: seed hooks status|install|uninstall|repair [repo-path] [--json] [--force]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Inspect and manage guarded git hooks.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Subcommands:")
+	fmt.Fprintln(w, "  status     Show core.hooksPath, script modes, and script versions compared with this CLI")
+	fmt.Fprintln(w, "  install    Write missing guarded scripts and run .seed/install-hooks.sh (--force chains existing hooks)")
+	fmt.Fprintln(w, "  uninstall  Restore the core.hooksPath recorded at install and remove chained-hook wrappers")
+	fmt.Fprintln(w, "  repair     Fix script modes, rewrite outdated scripts, and reinstall hooks if needed")
+}
+
+func runHooks(opts hooksOptions, out io.Writer) error {
+	if _, err := gitOutput(opts.repoPath, "rev-parse", "--is-inside-work-tree"); err != nil {
+		return fmt.Errorf("%s is not inside a git repository", opts.repoPath)
+	}
+	if opts.action != hooksStatus && !isGuardedRepo(worktreeSource{root: opts.repoPath}) {
+		return fmt.Errorf("%s is not a guarded Seed repo; hooks are only managed for the guarded profile", opts.repoPath)
+	}
+
+	actions := make([]string, 0)
+	var err error
+	switch opts.action {
+	case hooksInstall:
+		actions, err = installHooks(opts.repoPath, opts.force)
+	case hooksUninstall:
+		actions, err = uninstallHooks(opts.repoPath)
+	case hooksRepair:
+		actions, err = repairHooks(opts.repoPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	report, err := inspectHooks(opts.repoPath)
+	if err != nil {
+		return err
+	}
+	report.Actions = actions
+	return printHooksReport(out, report, opts.jsonOutput)
+}
+
+func isGuardedRepo(source repoSource) bool {
+	if source.isFile(".seed/seed-test.sh") {
+		return true
+	}
+	profile, err := parseManifestProfile(source)
+	return err == nil && profile == profileGuarded
+}
+
+func inspectHooks(repoPath string) (hooksReport, error) {
+	report := hooksReport{
+		RepoPath:       repoPath,
+		HooksPath:      gitConfigValue(repoPath, "core.hooksPath"),
+		PriorHooksPath: gitConfigValue(repoPath, "seed.priorHooksPath"),
+		PriorHooksDir:  gitConfigValue(repoPath, "seed.priorHooksDir"),
+		Scripts:        make([]hookScriptState, 0, len(guardedScripts)),
+	}
+	report.Installed = report.HooksPath != "" && sameDir(repoPath, report.HooksPath, seedHooksPath)
+	report.Healthy = report.Installed
+
+	for _, script := range guardedScripts {
+		state, _ := inspectScript(repoPath, script)
+		if state.State != scriptCurrent || !state.Executable {
+			report.Healthy = false
+		}
+		report.Scripts = append(report.Scripts, state)
+	}
+
+	wrappers, err := chainedHookWrappers(repoPath)
+	if err != nil {
+		return hooksReport{}, err
+	}
+	report.ChainedHooks = wrappers
+	return report, nil
+}
+
+func installHooks(repoPath string, force bool) ([]string, error) {
+	manifest, err := loadCanonicalManifest()
+	if err != nil {
+		return nil, err
+	}
+	actions := make([]string, 0)
+	for _, script := range guardedScripts {
+		if _, err := os.Stat(filepath.Join(repoPath, filepath.FromSlash(script.path))); err == nil {
+			continue
+		}
+		if err := writeGuardedScript(repoPath, script, manifest.SeedFormatVersion); err != nil {
+			return actions, err
+		}
+		actions = append(actions, "wrote missing "+script.path)
+	}
+	if err := runInstallHooksScript(repoPath, force); err != nil {
+		return actions, err
+	}
+	return append(actions, "ran .seed/install-hooks.sh"), nil
+}
+
+func uninstallHooks(repoPath string) ([]string, error) {
+	current := gitConfigValue(repoPath, "core.hooksPath")
+	if current == "" || !sameDir(repoPath, current, seedHooksPath) {
+		return []string{"Seed hooks are not installed; core.hooksPath left unchanged"}, nil
+	}
+
+	actions := make([]string, 0)
+	prior := gitConfigValue(repoPath, "seed.priorHooksPath")
+	// Seed's setting is local. Once it is gone, a prior value read from global or
+	// system config applies again, so it is only written back when it was local.
+	if _, err := gitOutput(repoPath, "config", "--local", "--unset", "core.hooksPath"); err != nil {
+		return actions, err
+	}
+	switch {
+	case prior == "":
+		actions = append(actions, "unset core.hooksPath")
+	case gitConfigValue(repoPath, "core.hooksPath") == prior:
+		actions = append(actions, "unset core.hooksPath; "+prior+" from global or system config applies again")
+	default:
+		if _, err := gitOutput(repoPath, "config", "--local", "core.hooksPath", prior); err != nil {
+			return actions, err
+		}
+		actions = append(actions, "restored core.hooksPath="+prior)
+	}
+
+	wrappers, err := chainedHookWrappers(repoPath)
+	if err != nil {
+		return actions, err
+	}
+	for _, name := range wrappers {
+		if err := os.Remove(filepath.Join(repoPath, filepath.FromSlash(seedHooksPath), name)); err != nil {
+			return actions, fmt.Errorf("remove chained hook %s: %w", name, err)
+		}
+		if err := removeLockEntry(repoPath, seedHooksPath+"/"+name); err != nil {
+			return actions, err
+		}
+		actions = append(actions, "removed chained hook wrapper "+seedHooksPath+"/"+name)
+	}
+
+	for _, key := range []string{"seed.priorHooksPath", "seed.priorHooksDir"} {
+		if gitConfigValue(repoPath, key) != "" {
+			_, _ = gitOutput(repoPath, "config", "--local", "--unset", key)
+		}
+	}
+	return actions, nil
+}
+
+func repairHooks(repoPath string) ([]string, error) {
+	manifest, err := loadCanonicalManifest()
+	if err != nil {
+		return nil, err
+	}
+	before, err := inspectHooks(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]string, 0)
+	for i, state := range before.Scripts {
+		script := guardedScripts[i]
+		switch {
+		case state.State == scriptModified:
+			actions = append(actions, fmt.Sprintf("skipped locally modified %s; review it with seed refresh-scripts", script.path))
+		case state.State != scriptCurrent:
+			if err := writeGuardedScript(repoPath, script, manifest.SeedFormatVersion); err != nil {
+				return actions, err
+			}
+			actions = append(actions, fmt.Sprintf("rewrote %s script %s", state.State, script.path))
+		case !state.Executable:
+			if err := os.Chmod(filepath.Join(repoPath, filepath.FromSlash(script.path)), 0o755); err != nil {
+				return actions, fmt.Errorf("chmod %s: %w", script.path, err)
+			}
+			actions = append(actions, "made "+script.path+" executable")
+		}
+	}
+
+	if !before.Installed {
+		if err := runInstallHooksScript(repoPath, false); err != nil {
+			return actions, err
+		}
+		actions = append(actions, "ran .seed/install-hooks.sh")
+	}
+	if len(actions) == 0 {
+		actions = append(actions, "nothing to repair")
+	}
+	return actions, nil
+}
+
+// chainedHookWrappers lists wrapper hooks generated for hooks that were active before Seed.
+func chainedHookWrappers(repoPath string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(repoPath, filepath.FromSlash(seedHooksPath)))
+	if errors.Is(err, os.ErrNotExist) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", seedHooksPath, err)
+	}
+	names := make([]string, 0)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(repoPath, filepath.FromSlash(seedHooksPath), entry.Name()))
+		if err == nil && bytes.Contains(content, []byte(chainedHookMarker)) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func gitConfigValue(repoPath, key string) string {
+	value, err := gitOutput(repoPath, "config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
+
+// sameDir compares a git path setting, which is relative to the worktree root, with a repo-relative dir.
+func sameDir(repoPath, setting, relative string) bool {
+	resolve := func(path string) string {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(repoPath, path)
+		}
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			return resolved
+		}
+		return filepath.Clean(path)
+	}
+	return resolve(setting) == resolve(relative)
+}
+
+func printHooksReport(out io.Writer, report hooksReport, jsonOutput bool) error {
+	if jsonOutput {
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal hooks status: %w", err)
+		}
+		fmt.Fprintln(out, string(encoded))
+		return nil
+	}
+
+	for _, action := range report.Actions {
+		fmt.Fprintf(out, "- %s\n", action)
+	}
+	state := "not installed"
+	if report.Installed {
+		state = "installed"
+	}
+	hooksPath := report.HooksPath
+	if hooksPath == "" {
+		hooksPath = "(unset)"
+	}
+	fmt.Fprintf(out, "Seed hooks: %s (core.hooksPath=%s)\n", state, hooksPath)
+	if report.PriorHooksPath != "" || report.PriorHooksDir != "" {
+		fmt.Fprintf(out, "Prior hooks: core.hooksPath=%s dir=%s\n", report.PriorHooksPath, report.PriorHooksDir)
+	}
+	for _, script := range report.Scripts {
+		mode := "executable"
+		if !script.Executable {
+			mode = "not executable"
+		}
+		if script.State == scriptMissing {
+			mode = "-"
+		}
+		version := script.Version
+		if version == "" {
+			version = "-"
+		}
+		fmt.Fprintf(out, "  %-24s %-8s %-14s seed %s\n", script.Path, script.State, mode, version)
+	}
+	if len(report.ChainedHooks) > 0 {
+		fmt.Fprintf(out, "Chained hooks: %s\n", strings.Join(report.ChainedHooks, ", "))
+	}
+	if !report.Healthy {
+		fmt.Fprintln(out, "Run seed hooks repair to fix modes, outdated scripts, or a missing install; review modified scripts with seed refresh-scripts.")
+	}
+	return nil
+}
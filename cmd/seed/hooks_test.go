@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGuardedInstallChainsExistingHooks(t *testing.T) {
+	requireGit(t)
+
+	manifest := mustLoadManifest(t)
+	tmpRoot := t.TempDir()
+	target := filepath.Join(tmpRoot, "guarded")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatalf("mkdir target: %v", err)
+	}
+	// Scaffolding needs an empty target, so the prior hooks live in a shared directory.
+	marker := filepath.Join(tmpRoot, "prior-hook-ran")
+	priorHooks := filepath.Join(tmpRoot, "shared-hooks")
+	runCommandMustSucceed(t, exec.Command("git", "-C", target, "init"))
+	runCommandMustSucceed(t, exec.Command("git", "-C", target, "config", "core.hooksPath", priorHooks))
+	if err := os.MkdirAll(priorHooks, 0o755); err != nil {
+		t.Fatalf("mkdir prior hooks: %v", err)
+	}
+	for _, name := range []string{"pre-commit", "commit-msg"} {
+		script := fmt.Sprintf("#!/bin/sh\necho %s >> %q\n", name, marker)
+		if err := os.WriteFile(filepath.Join(priorHooks, name), []byte(script), 0o755); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	err := scaffoldProfile(target, profileGuarded, manifest)
+	if err == nil || !strings.Contains(err.Error(), "install-hooks.sh --force") {
+		t.Fatalf("expected install to refuse existing hooks, got %v", err)
+	}
+	installer := filepath.Join(target, ".seed", "install-hooks.sh")
+	runCommandMustSucceed(t, exec.Command("sh", installer, "--force"))
+
+	for key, want := range map[string]string{
+		"core.hooksPath":      ".seed/hooks",
+		"seed.priorHooksPath": priorHooks,
+		"seed.priorHooksDir":  priorHooks,
+	} {
+		got := strings.TrimSpace(runCommandMustSucceed(t, exec.Command("git", "-C", target, "config", "--local", "--get", key)))
+		if got != want {
+			t.Fatalf("%s = %q, want %q", key, got, want)
+		}
+	}
+	mustBeFile(t, filepath.Join(target, ".seed", "hooks", "commit-msg"))
+	runCommandMustSucceed(t, exec.Command("sh", installer))
+
+	mustResolvePlaceholders(t, target)
+	runCommandMustSucceed(t, exec.Command("git", "-C", target, "add", "-A"))
+	mustGitCommit(t, target, "scaffold", true)
+	if ran := mustReadFile(t, marker); ran != "pre-commit\ncommit-msg\n" {
+		t.Fatalf("prior hooks did not run in order: %q", ran)
+	}
+
+	// Uninstalling drops the wrapper from the lock too, so verify still passes.
+	var out bytes.Buffer
+	if code, err := runVerify(verifyOptions{repoPath: target, update: true}, &out); err != nil || code != 0 {
+		t.Fatalf("verify --update: %d %v\n%s", code, err, out.String())
+	}
+	out.Reset()
+	if err := runHooks(hooksOptions{action: hooksUninstall, repoPath: target}, &out); err != nil {
+		t.Fatalf("hooks uninstall: %v", err)
+	}
+	mustBeMissing(t, filepath.Join(target, ".seed", "hooks", "commit-msg"))
+	if _, ok := mustReadLock(t, target).Files[".seed/hooks/commit-msg"]; ok {
+		t.Fatal("uninstall should drop the removed wrapper from the lock")
+	}
+	out.Reset()
+	if code, err := runVerify(verifyOptions{repoPath: target}, &out); err != nil || code != 0 {
+		t.Fatalf("verify after uninstall: %d %v\n%s", code, err, out.String())
+	}
+	if got := strings.TrimSpace(runCommandMustSucceed(t, exec.Command("git", "-C", target, "config", "--local", "--get", "core.hooksPath"))); got != priorHooks {
+		t.Fatalf("uninstall should restore the local core.hooksPath, got %q", got)
+	}
+}
+
+func TestHooksUninstallLeavesGlobalHooksPath(t *testing.T) {
+	requireGit(t)
+
+	manifest := mustLoadManifest(t)
+	tmpRoot := t.TempDir()
+	globalHooks := filepath.Join(tmpRoot, "global-hooks")
+	globalConfig := filepath.Join(tmpRoot, "gitconfig")
+	if err := writeFile(globalConfig, "[core]\n\thooksPath = "+globalHooks+"\n", 0o644); err != nil {
+		t.Fatalf("write global config: %v", err)
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", globalConfig)
+
+	target := filepath.Join(tmpRoot, "guarded")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatalf("mkdir target: %v", err)
+	}
+	runCommandMustSucceed(t, exec.Command("git", "-C", target, "init"))
+	if err := scaffoldProfile(target, profileGuarded, manifest); err == nil {
+		t.Fatal("expected install to refuse the global core.hooksPath without --force")
+	}
+	runCommandMustSucceed(t, exec.Command("sh", filepath.Join(target, ".seed", "install-hooks.sh"), "--force"))
+
+	var out bytes.Buffer
+	if err := runHooks(hooksOptions{action: hooksUninstall, repoPath: target}, &out); err != nil {
+		t.Fatalf("hooks uninstall: %v", err)
+	}
+	if local, code := runCommandWithExit(t, exec.Command("git", "-C", target, "config", "--local", "--get", "core.hooksPath")); code == 0 {
+		t.Fatalf("a global core.hooksPath must not be copied into the repo config, got %q\n%s", local, out.String())
+	}
+	if got := strings.TrimSpace(runCommandMustSucceed(t, exec.Command("git", "-C", target, "config", "--get", "core.hooksPath"))); got != globalHooks {
+		t.Fatalf("the global core.hooksPath should apply again, got %q", got)
+	}
+}
+
+func TestHooksCommand(t *testing.T) {
+	requireGit(t)
+
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "guarded")
+	mustScaffoldGitRepo(t, target, profileGuarded, manifest)
+
+	report, err := inspectHooks(target)
+	if err != nil {
+		t.Fatalf("inspect hooks: %v", err)
+	}
+	if !report.Installed || !report.Healthy {
+		t.Fatalf("fresh guarded scaffold should report healthy hooks: %+v", report)
+	}
+
+	prePush := filepath.Join(target, ".seed", "hooks", "pre-push")
+	if err := os.Chmod(prePush, 0o644); err != nil {
+		t.Fatalf("chmod pre-push: %v", err)
+	}
+	// A stamped copy generated from older script content is outdated, not modified.
+	mustRewriteFile(t, filepath.Join(target, ".seed", "seed-test.sh"), func(string) string {
+		return stampScript(guardedSeedTestScript+"# older release\n", manifest.SeedFormatVersion)
+	})
+
+	var out bytes.Buffer
+	if err := runHooks(hooksOptions{action: hooksStatus, repoPath: target, jsonOutput: true}, &out); err != nil {
+		t.Fatalf("hooks status: %v", err)
+	}
+	var status hooksReport
+	if err := json.Unmarshal(out.Bytes(), &status); err != nil {
+		t.Fatalf("decode hooks status: %v\n%s", err, out.String())
+	}
+	if status.Healthy || status.Scripts[0].State != scriptOutdated || status.Scripts[2].Executable {
+		t.Fatalf("status should flag the outdated script and mode: %+v", status)
+	}
+
+	out.Reset()
+	if err := runHooks(hooksOptions{action: hooksRepair, repoPath: target}, &out); err != nil {
+		t.Fatalf("hooks repair: %v", err)
+	}
+	for _, want := range []string{"rewrote outdated script .seed/seed-test.sh", "made .seed/hooks/pre-push executable"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("repair output missing %q: %s", want, out.String())
+		}
+	}
+	if report, _ := inspectHooks(target); !report.Healthy {
+		t.Fatalf("repair should leave hooks healthy: %+v", report)
+	}
+
+	out.Reset()
+	if err := runHooks(hooksOptions{action: hooksUninstall, repoPath: target}, &out); err != nil {
+		t.Fatalf("hooks uninstall: %v", err)
+	}
+	if _, code := runCommandWithExit(t, exec.Command("git", "-C", target, "config", "--local", "--get", "core.hooksPath")); code == 0 {
+		t.Fatalf("uninstall should unset core.hooksPath: %s", out.String())
+	}
+
+	out.Reset()
+	if err := runHooks(hooksOptions{action: hooksInstall, repoPath: target}, &out); err != nil {
+		t.Fatalf("hooks install: %v", err)
+	}
+	if !strings.Contains(out.String(), "Seed hooks: installed (core.hooksPath=.seed/hooks)") {
+		t.Fatalf("install should configure hooks: %s", out.String())
+	}
+}
+
+func TestPreCommitValidatesStagedContent(t *testing.T) {
+	requireGit(t)
+
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "guarded")
+	mustScaffoldGitRepo(t, target, profileGuarded, manifest)
+	mustResolvePlaceholders(t, target)
+	runCommandMustSucceed(t, exec.Command("git", "-C", target, "add", "-A"))
+
+	seedTest := filepath.Join(target, ".seed", "seed-test.sh")
+	readmePath := filepath.Join(target, "README.md")
+	fixed := mustReadFile(t, readmePath)
+	broken := strings.Replace(fixed, "## Quick Start\n", "## Run It\n", 1)
+
+	// Broken working tree, fixed index: staged validation passes.
+	mustRewriteFile(t, readmePath, func(string) string { return broken })
+	if output, code := runCommandWithExit(t, exec.Command(seedTest, "--staged")); code != 0 {
+		t.Fatalf("expected staged validation to ignore unstaged breakage, exit=%d output=%s", code, output)
+	}
+	if output, code := runCommandWithExit(t, exec.Command(seedTest)); code != 1 {
+		t.Fatalf("expected worktree validation to see unstaged breakage, exit=%d output=%s", code, output)
+	}
+	mustGitCommit(t, target, "commit with unstaged breakage", true)
+
+	// Broken index, fixed working tree: staged validation and the hook block.
+	runCommandMustSucceed(t, exec.Command("git", "-C", target, "add", "README.md"))
+	mustRewriteFile(t, readmePath, func(string) string { return fixed })
+	output, code := runCommandWithExit(t, exec.Command(seedTest, "--staged"))
+	if code != 1 || !strings.Contains(output, `Missing required heading "Quick Start" in README.md`) {
+		t.Fatalf("expected staged validation to fail, exit=%d output=%s", code, output)
+	}
+	head := runCommandMustSucceed(t, exec.Command("git", "-C", target, "rev-parse", "HEAD"))
+	mustGitCommit(t, target, "commit staged breakage", false)
+	if after := runCommandMustSucceed(t, exec.Command("git", "-C", target, "rev-parse", "HEAD")); after != head {
+		t.Fatalf("blocked commit moved HEAD from %s to %s", head, after)
+	}
+	if staged := runCommandMustSucceed(t, exec.Command("git", "-C", target, "diff", "--cached", "--name-only")); strings.TrimSpace(staged) != "README.md" {
+		t.Fatalf("blocked commit should leave README.md staged, got %q", staged)
+	}
+
+	// Working-tree fallback restores the old behaviour.
+	t.Setenv("SEED_HOOK_SOURCE", "worktree")
+	mustGitCommit(t, target, "commit with worktree fallback", true)
+}
+
+func TestPrePushValidatesEveryCommit(t *testing.T) {
+	requireGit(t)
+
+	manifest := mustLoadManifest(t)
+	tmpRoot := t.TempDir()
+	remote := filepath.Join(tmpRoot, "remote.git")
+	runCommandMustSucceed(t, exec.Command("git", "init", "--bare", "-q", remote))
+
+	target := filepath.Join(tmpRoot, "guarded")
+	mustScaffoldGitRepo(t, target, profileGuarded, manifest)
+	mustBeFile(t, filepath.Join(target, ".seed", "hooks", "pre-push"))
+	mustResolvePlaceholders(t, target)
+	runCommandMustSucceed(t, exec.Command("git", "-C", target, "add", "-A"))
+	mustGitCommit(t, target, "scaffold", true)
+	runCommandMustSucceed(t, exec.Command("git", "-C", target, "remote", "add", "origin", remote))
+	runCommandMustSucceed(t, exec.Command("git", "-C", target, "push", "-q", "origin", "HEAD:refs/heads/main"))
+
+	// Commits bypass pre-commit as with --no-verify; the breaking commit is followed by a clean one.
+	mustRewriteFile(t, filepath.Join(target, "README.md"), func(content string) string {
+		return strings.Replace(content, "## Quick Start\n", "## Run It\n", 1)
+	})
+	mustCommitAll(t, target, "break readme")
+	breaking := strings.TrimSpace(runCommandMustSucceed(t, exec.Command("git", "-C", target, "rev-parse", "HEAD")))
+	if err := os.WriteFile(filepath.Join(target, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("write main.go: %v", err)
+	}
+	mustCommitAll(t, target, "add code")
+
+	pushed := runCommandMustSucceed(t, exec.Command("git", "-C", remote, "rev-parse", "main"))
+	output, code := runCommandWithExit(t, exec.Command("git", "-C", target, "push", "origin", "HEAD:refs/heads/main"))
+	if code == 0 || !strings.Contains(output, "SEED_PUSH_BLOCKED_COMMIT="+breaking) {
+		t.Fatalf("expected push to be blocked at %s, exit=%d output=%s", breaking, code, output)
+	}
+	if after := runCommandMustSucceed(t, exec.Command("git", "-C", remote, "rev-parse", "main")); after != pushed {
+		t.Fatalf("blocked push moved the remote main from %s to %s", pushed, after)
+	}
+
+	output, code = runCommandWithExit(t, exec.Command("git", "-C", target, "push", "origin", "HEAD~2:refs/heads/topic"))
+	if code != 0 || !strings.Contains(output, "SEED_PUSH_COMMITS_CHECKED=0") {
+		t.Fatalf("expected already-pushed commits to be skipped, exit=%d output=%s", code, output)
+	}
+	runCommandMustSucceed(t, exec.Command("git", "-C", remote, "rev-parse", "--verify", "topic"))
+}
@@ -0,0 +1,488 @@
+package main
+
+// Layout rules mirror the checks in the generated seed-test.sh so every profile can be validated from Go.
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	reasonMissingManifest  = "missing_manifest"
+	reasonMissingFile      = "missing_file"
+	reasonMissingHeading   = "missing_heading"
+	reasonHeadingAlias     = "heading_alias"
+	reasonHeadingNearMiss  = "heading_near_miss"
+	reasonMisplacedContent = "misplaced_content"
+	reasonMisplacedPhrase  = "misplaced_phrase"
+	reasonWarningsAsErrors = "warnings_as_errors"
+)
+
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+	// severityInfo findings are printed but never counted or used as trigger reasons.
+	severityInfo = "info"
+)
+
+const (
+	statusOK               = "ok"
+	statusFail             = "fail"
+	statusSkillRecommended = "skill_recommended"
+)
+
+// seedDocs are the root documents owned by the Seed contract.
+var seedDocs = []string{"README.md", "DECISIONS.md", "TODO.md", "CONTEXT.md", "AGENTS.md"}
+
+type misplacedPhraseRules struct {
+	// MinScore is the number of matching lines before a file is reported; values below 1 mean 1.
+	MinScore int `json:"min_score"`
+	// Signals are "DOC.md::phrase" specs naming the Seed doc the phrase belongs in.
+	Signals []string `json:"signals"`
+}
+
+type headingNearMissRules struct {
+	// MaxEditDistance bounds the edit distance between normalized headings.
+	MaxEditDistance int `json:"max_edit_distance"`
+	// MaxDistanceRatio bounds the distance relative to the expected heading length; zero disables it.
+	MaxDistanceRatio float64 `json:"max_distance_ratio"`
+}
+
+type layoutFinding struct {
+	Reason   string `json:"reason"`
+	Severity string `json:"severity"`
+	File     string `json:"file,omitempty"`
+	Message  string `json:"message"`
+}
+
+type layoutReport struct {
+	Findings []layoutFinding
+}
+
+func (r *layoutReport) addError(reason, file, format string, args ...any) {
+	r.Findings = append(r.Findings, layoutFinding{
+		Reason:   reason,
+		Severity: severityError,
+		File:     file,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (r *layoutReport) addWarning(reason, file, format string, args ...any) {
+	r.Findings = append(r.Findings, layoutFinding{
+		Reason:   reason,
+		Severity: severityWarning,
+		File:     file,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (r *layoutReport) addInfo(reason, file, format string, args ...any) {
+	r.Findings = append(r.Findings, layoutFinding{
+		Reason:   reason,
+		Severity: severityInfo,
+		File:     file,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (r layoutReport) count(severity string) int {
+	total := 0
+	for _, finding := range r.Findings {
+		if finding.Severity == severity {
+			total++
+		}
+	}
+	return total
+}
+
+// triggerReasons returns reasons in first-seen order, matching SEED_TRIGGER_REASONS.
+func (r layoutReport) triggerReasons() []string {
+	seen := map[string]bool{}
+	reasons := make([]string, 0, len(r.Findings))
+	for _, finding := range r.Findings {
+		if finding.Severity == severityInfo || seen[finding.Reason] {
+			continue
+		}
+		seen[finding.Reason] = true
+		reasons = append(reasons, finding.Reason)
+	}
+	return reasons
+}
+
+// outcome maps findings to the seed-test.sh status contract and exit code.
+func (r layoutReport) outcome(warningsAsErrors bool) (string, []string, int) {
+	reasons := r.triggerReasons()
+	switch {
+	case r.count(severityError) > 0:
+		return statusFail, reasons, 1
+	case r.count(severityWarning) > 0 && warningsAsErrors:
+		return statusFail, append(reasons, reasonWarningsAsErrors), 1
+	case r.count(severityWarning) > 0:
+		return statusSkillRecommended, reasons, 2
+	default:
+		return statusOK, reasons, 0
+	}
+}
+
+// loadRepoRules prefers the repo-local manifest snapshot and falls back to canonical rules for the profile.
+func loadRepoRules(source repoSource, profile string) (manifestSnapshot, bool, error) {
+	bytes, err := source.readFile(".seed/manifest.json")
+	if err == nil {
+		// The format is checked before the schema so fields from a newer Seed read as a version problem.
+		var header struct {
+			SeedFormatVersion string `json:"seed_format_version"`
+		}
+		if err := json.Unmarshal(bytes, &header); err != nil {
+			return manifestSnapshot{}, true, fmt.Errorf("parse .seed/manifest.json: %w", err)
+		}
+		manifest, err := loadCanonicalManifest()
+		if err != nil {
+			return manifestSnapshot{}, true, err
+		}
+		if compareFormatVersions(header.SeedFormatVersion, manifest.SeedFormatVersion) > 0 {
+			return manifestSnapshot{}, true, newerFormatError(header.SeedFormatVersion, manifest.SeedFormatVersion)
+		}
+		var snapshot manifestSnapshot
+		if err := decodeManifest(bytes, seededSchemaPath, &snapshot); err != nil {
+			return manifestSnapshot{}, true, fmt.Errorf("invalid .seed/manifest.json: %w", err)
+		}
+		return snapshot.compileGlobs(), true, nil
+	}
+
+	manifest, err := loadCanonicalManifest()
+	if err != nil {
+		return manifestSnapshot{}, false, err
+	}
+	snapshot, err := manifestForProfile(manifest, profile)
+	return snapshot.compileGlobs(), false, err
+}
+
+// compileGlobs compiles the scan scope and file rule globs once, so checks over
+// every repo file do not recompile them per path.
+func (rules manifestSnapshot) compileGlobs() manifestSnapshot {
+	rules.MisplacedContentScope = rules.MisplacedContentScope.compile()
+	if len(rules.FileRules) > 0 {
+		compiled := make(map[string]fileRule, len(rules.FileRules))
+		for name, rule := range rules.FileRules {
+			compiled[name] = rule.compile()
+		}
+		rules.FileRules = compiled
+	}
+	return rules
+}
+
+// validateContract runs every rule with the repo overrides applied; findings then
+// take the severities from the manifest and .seed/overrides.json.
+func validateContract(source repoSource, rules manifestSnapshot, opts contractOptions) layoutReport {
+	report := layoutReport{}
+	// Like seed-test.sh, stop at a missing rules file: nothing else can be trusted.
+	if checkMissingRulesFile(source, &report) {
+		return report
+	}
+	overrides := checkOverrides(source, &report)
+	rules = overrides.apply(rules)
+	checkFormatVersion(rules, &report)
+	checkRulesFile(source, &report)
+	checkValidatorLock(source, rules, opts, &report)
+	checkRequiredFiles(source, rules, &report)
+	checkFileRules(source, rules, &report)
+	if rules.checksHeadings() {
+		checkRequiredHeadings(source, rules, &report)
+	}
+	checkContentRules(source, rules, opts, &report)
+	checkDecisions(source, rules, &report)
+	checkTodo(source, rules, &report)
+	checkMisplacedContent(source, rules, &report)
+	report.Findings = adjustFindings(report.Findings, rules.RuleSeverities, overrides)
+	return report
+}
+
+// checksHeadings reports whether required headings are checked. Core and llm
+// repos never promised heading checks; seed-test.sh adds them in guarded repos.
+func (rules manifestSnapshot) checksHeadings() bool {
+	return rules.ActiveProfile == profileGuarded
+}
+
+func checkRequiredFiles(source repoSource, rules manifestSnapshot, report *layoutReport) {
+	for _, requiredFile := range rules.RequiredFiles {
+		if !source.isFile(requiredFile) {
+			report.addError(reasonMissingFile, requiredFile, "Missing required Seed artifact: %s", requiredFile)
+		}
+	}
+}
+
+func checkRequiredHeadings(source repoSource, rules manifestSnapshot, report *layoutReport) {
+	for _, spec := range rules.RequiredHeadings {
+		headingFile, headingName := splitHeadingSpec(spec)
+		content, err := source.readFile(headingFile)
+		if err != nil {
+			continue
+		}
+		headings := markdownHeadings(string(content))
+		if containsString(headings, headingName) {
+			continue
+		}
+
+		if alias, ok := matchHeadingAlias(headingFile, headingName, headings, rules.HeadingAliases); ok {
+			report.addWarning(reasonHeadingAlias, headingFile,
+				"Heading alias detected in %s: expected \"%s\", found \"%s\"", headingFile, headingName, alias)
+			continue
+		}
+
+		skip := knownHeadingNames(headingFile, rules)
+		if nearMiss, ok := nearMissHeading(headingName, headings, skip, rules.HeadingNearMiss); ok {
+			report.addWarning(reasonHeadingNearMiss, headingFile,
+				"Heading near miss in %s: expected \"%s\", found \"%s\" (rename \"## %s\" to \"## %s\")",
+				headingFile, headingName, nearMiss, nearMiss, headingName)
+			continue
+		}
+
+		report.addError(reasonMissingHeading, headingFile, "Missing required heading \"%s\" in %s", headingName, headingFile)
+	}
+}
+
+func checkMisplacedContent(source repoSource, rules manifestSnapshot, report *layoutReport) {
+	textFiles := make([]string, 0)
+	commentFiles := make([]string, 0)
+	for _, path := range source.listFiles() {
+		if containsString(seedDocs, path) || strings.HasPrefix(path, ".seed/") || strings.HasPrefix(path, "skills/") {
+			continue
+		}
+		switch rules.MisplacedContentScope.classify(path) {
+		case scanText:
+			textFiles = append(textFiles, path)
+		case scanComments:
+			commentFiles = append(commentFiles, path)
+		}
+	}
+
+	for _, textFile := range textFiles {
+		content, err := source.readFile(textFile)
+		if err != nil {
+			continue
+		}
+		headings := markdownHeadings(string(content))
+		for _, signal := range rules.MisplacedContentSignal {
+			if containsString(headings, signal) {
+				report.addWarning(reasonMisplacedContent, textFile,
+					"Potential misplaced Seed content in %s: heading \"%s\"", textFile, signal)
+				break
+			}
+		}
+		checkMisplacedPhrases(textFile, string(content), false, rules.MisplacedContentPhrases, report)
+	}
+	for _, commentFile := range commentFiles {
+		content, err := source.readFile(commentFile)
+		if err != nil {
+			continue
+		}
+		checkMisplacedPhrases(commentFile, string(content), true, rules.MisplacedContentPhrases, report)
+	}
+}
+
+// commentMarkerPattern matches the comment prefixes recognised in source files.
+var commentMarkerPattern = regexp.MustCompile(`^[ \t]*(//|/\*|\*|#|--|;)`)
+
+type phraseMatch struct {
+	score  int
+	phrase string
+	line   int
+}
+
+// checkMisplacedPhrases scores a file against "DOC.md::phrase" signals. Each
+// line containing a phrase (ASCII case-insensitive) adds one point to that doc;
+// for source files only lines starting with a comment marker count.
+func checkMisplacedPhrases(path, content string, comments bool, rules misplacedPhraseRules, report *layoutReport) {
+	if len(rules.Signals) == 0 {
+		return
+	}
+	docs := make([]string, 0)
+	matches := map[string]*phraseMatch{}
+	for i, line := range strings.Split(content, "\n") {
+		if comments {
+			marker := commentMarkerPattern.FindString(line)
+			if marker == "" {
+				continue
+			}
+			line = line[len(marker):]
+		}
+		line = asciiLower(line)
+		hitDocs := map[string]bool{}
+		for _, spec := range rules.Signals {
+			doc, phrase, found := strings.Cut(spec, "::")
+			if !found || phrase == "" || hitDocs[doc] || !strings.Contains(line, asciiLower(phrase)) {
+				continue
+			}
+			hitDocs[doc] = true
+			match, ok := matches[doc]
+			if !ok {
+				match = &phraseMatch{phrase: phrase, line: i + 1}
+				matches[doc] = match
+			}
+			match.score++
+		}
+	}
+
+	for _, spec := range rules.Signals {
+		doc, _, found := strings.Cut(spec, "::")
+		if found && !containsString(docs, doc) {
+			docs = append(docs, doc)
+		}
+	}
+	minScore := max(rules.MinScore, 1)
+	for _, doc := range docs {
+		match, ok := matches[doc]
+		if !ok || match.score < minScore {
+			continue
+		}
+		report.addWarning(reasonMisplacedPhrase, path,
+			"Possible misplaced Seed content in %s (score %d): \"%s\" at line %d; consider moving it to %s",
+			path, match.score, match.phrase, match.line, doc)
+	}
+}
+
+// asciiLower folds only A-Z, like awk tolower in the C locale.
+func asciiLower(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' {
+			return r + ('a' - 'A')
+		}
+		return r
+	}, s)
+}
+
+// splitHeadingSpec parses "file::heading" specs from the manifest.
+func splitHeadingSpec(spec string) (string, string) {
+	file, heading, found := strings.Cut(spec, "::")
+	if !found {
+		return spec, spec
+	}
+	return file, heading
+}
+
+// splitAliasSpec parses "file::canonical::alias" specs from the manifest.
+func splitAliasSpec(spec string) (string, string, string) {
+	file, rest := splitHeadingSpec(spec)
+	canonical, alias := splitHeadingSpec(rest)
+	return file, canonical, alias
+}
+
+func matchHeadingAlias(headingFile, headingName string, headings, aliases []string) (string, bool) {
+	for _, spec := range aliases {
+		aliasFile, canonical, alias := splitAliasSpec(spec)
+		if aliasFile == headingFile && canonical == headingName && containsString(headings, alias) {
+			return alias, true
+		}
+	}
+	return "", false
+}
+
+// knownHeadingNames lists required and alias headings for a file so valid sections are never offered as renames.
+func knownHeadingNames(headingFile string, rules manifestSnapshot) []string {
+	names := make([]string, 0)
+	for _, spec := range rules.RequiredHeadings {
+		file, heading := splitHeadingSpec(spec)
+		if file == headingFile {
+			names = append(names, heading)
+		}
+	}
+	for _, spec := range rules.HeadingAliases {
+		file, _, alias := splitAliasSpec(spec)
+		if file == headingFile {
+			names = append(names, alias)
+		}
+	}
+	return names
+}
+
+// markdownHeadings returns the text of every "## " line, matching grep -Fqx "## <name>" semantics.
+func markdownHeadings(content string) []string {
+	headings := make([]string, 0)
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			headings = append(headings, strings.TrimPrefix(line, "## "))
+		}
+	}
+	return headings
+}
+
+// nearMissHeading returns the closest candidate heading within the configured thresholds.
+func nearMissHeading(expected string, candidates, skip []string, limits headingNearMissRules) (string, bool) {
+	target := normalizeHeading(expected)
+	if target == "" {
+		return "", false
+	}
+
+	best := -1
+	found := ""
+	for _, candidate := range candidates {
+		if containsString(skip, candidate) {
+			continue
+		}
+		distance := editDistance(target, normalizeHeading(candidate))
+		if distance > limits.MaxEditDistance {
+			continue
+		}
+		if limits.MaxDistanceRatio > 0 && float64(distance) > limits.MaxDistanceRatio*float64(len(target)) {
+			continue
+		}
+		if best < 0 || distance < best {
+			best = distance
+			found = candidate
+		}
+	}
+	return found, best >= 0
+}
+
+// normalizeHeading folds case and drops everything except ASCII letters and digits.
+func normalizeHeading(heading string) string {
+	builder := strings.Builder{}
+	for i := 0; i < len(heading); i++ {
+		c := heading[i]
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			builder.WriteByte(c)
+		}
+	}
+	return builder.String()
+}
+
+// editDistance is the Levenshtein distance between two ASCII strings.
+func editDistance(a, b string) int {
+	if a == "" {
+		return len(b)
+	}
+	if b == "" {
+		return len(a)
+	}
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
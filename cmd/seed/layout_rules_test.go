@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeadingNearMiss(t *testing.T) {
+	requireGit(t)
+
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "guarded")
+	mustScaffoldGitRepo(t, target, profileGuarded, manifest)
+
+	readmePath := filepath.Join(target, "README.md")
+	mustRewriteFile(t, readmePath, func(content string) string {
+		content = strings.Replace(content, "## Quick Start\n", "## Quickstart\n", 1)
+		content = strings.Replace(content, "## Known Limitations\n", "## Known limitations\n", 1)
+		return strings.Replace(content, "## Current Status\n", "## Status\n", 1)
+	})
+
+	seedOutput, seedCode := runCommandWithExit(t, exec.Command(filepath.Join(target, ".seed", "seed-test.sh")))
+	if seedCode != 1 {
+		t.Fatalf("expected seed-test to fail on missing heading, exit=%d output=%s", seedCode, seedOutput)
+	}
+	for _, want := range []string{
+		"SEED_WARNINGS=2",
+		"SEED_TRIGGER_REASONS=heading_near_miss,missing_heading",
+		`(rename "## Quickstart" to "## Quick Start")`,
+		`(rename "## Known limitations" to "## Known Limitations")`,
+	} {
+		if !strings.Contains(seedOutput, want) {
+			t.Fatalf("expected %q in seed-test output: %s", want, seedOutput)
+		}
+	}
+
+	rules, _, err := loadRepoRules(worktreeSource{root: target}, profileGuarded)
+	if err != nil {
+		t.Fatalf("load repo rules: %v", err)
+	}
+	report := validateContract(worktreeSource{root: target}, rules, contractOptions{today: time.Now()})
+	status, reasons, _ := report.outcome(rules.WarningsAsErrors)
+	if status != statusFail || report.count(severityWarning) != 2 || strings.Join(reasons, ",") != "heading_near_miss,missing_heading" {
+		t.Fatalf("go rules disagree with seed-test: status=%s reasons=%v findings=%v", status, reasons, report.Findings)
+	}
+
+	if nearMiss, ok := nearMissHeading("Quick Start", []string{"Quik Start"}, nil, headingNearMissRules{}); ok {
+		t.Fatalf("unexpected near miss with zero edit distance: %q", nearMiss)
+	}
+}
+
+func TestMisplacedPhrases(t *testing.T) {
+	rules := misplacedPhraseRules{
+		MinScore: 1,
+		Signals:  []string{"DECISIONS.md::we decided", "TODO.md::next step"},
+	}
+
+	// A banned phrase is reported with the file, its first line and the doc it belongs in.
+	report := layoutReport{}
+	checkMisplacedPhrases("docs/notes.md", "# Notes\n\nIntro.\nIn the end We Decided to use Go.\n", false, rules, &report)
+	want := `Possible misplaced Seed content in docs/notes.md (score 1): "we decided" at line 4; consider moving it to DECISIONS.md`
+	if len(report.Findings) != 1 || report.Findings[0].Reason != reasonMisplacedPhrase ||
+		report.Findings[0].File != "docs/notes.md" || report.Findings[0].Message != want {
+		t.Fatalf("unexpected findings: %+v", report.Findings)
+	}
+
+	// Text without a signal phrase passes, and so does a phrase outside comments in source files.
+	report = layoutReport{}
+	checkMisplacedPhrases("docs/notes.md", "# Notes\n\nWe considered Go.\n", false, rules, &report)
+	checkMisplacedPhrases("main.go", "// Package main runs the tool.\nvar reason = \"we decided\"\n", true, rules, &report)
+	if len(report.Findings) != 0 {
+		t.Fatalf("allowed text should pass: %+v", report.Findings)
+	}
+}
@@ -0,0 +1,316 @@
+package main
+
+// The lock records what Seed generated so hand or agent edits to its artifacts can be detected.
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const commandVerify = "verify"
+
+const (
+	lockFilePath           = ".seed/lock.json"
+	reasonValidatorChanged = "validator_modified"
+)
+
+// lockedDirs hold the files Seed generates; user docs are expected to change and are not locked.
+var lockedDirs = []string{".seed", "skills/seed-validate"}
+
+const (
+	lockUnchanged = "unchanged"
+	lockModified  = "modified"
+	lockMissing   = "missing"
+	lockExtra     = "extra"
+)
+
+type seedLock struct {
+	GeneratedBy string `json:"generated_by"`
+	// Files maps slash-separated repo paths to the SHA-256 of their content.
+	Files map[string]string `json:"files"`
+}
+
+type integrityRules struct {
+	// BlockValidatorEdits makes seed-test.sh --pre-commit fail when a guarded script differs from the lock.
+	BlockValidatorEdits bool `json:"block_validator_edits"`
+}
+
+type verifyOptions struct {
+	repoPath   string
+	update     bool
+	jsonOutput bool
+}
+
+type lockFileState struct {
+	Path     string `json:"path"`
+	State    string `json:"state"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+type verifyReport struct {
+	Lock    string          `json:"lock"`
+	Files   []lockFileState `json:"files"`
+	Summary map[string]int  `json:"summary"`
+}
+
+// lockedFiles lists the files currently under lockedDirs, excluding the lock itself
+// and the repo's own overrides, which are user-authored.
+func lockedFiles(repoPath string) ([]string, error) {
+	paths := make([]string, 0)
+	for _, dir := range lockedDirs {
+		root := filepath.Join(repoPath, filepath.FromSlash(dir))
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(repoPath, path)
+			if err != nil {
+				return err
+			}
+			if rel = filepath.ToSlash(rel); rel != lockFilePath && rel != overridesPath {
+				paths = append(paths, rel)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", dir, err)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// writeLockFile records the current content of every generated file.
+func writeLockFile(repoPath string) (seedLock, error) {
+	paths, err := lockedFiles(repoPath)
+	if err != nil {
+		return seedLock{}, err
+	}
+	lock := seedLock{GeneratedBy: cliVersion, Files: make(map[string]string, len(paths))}
+	for _, path := range paths {
+		content, err := os.ReadFile(filepath.Join(repoPath, filepath.FromSlash(path)))
+		if err != nil {
+			return seedLock{}, fmt.Errorf("read %s: %w", path, err)
+		}
+		lock.Files[path] = contentHash(string(content))
+	}
+	return lock, saveLock(repoPath, lock)
+}
+
+func saveLock(repoPath string, lock seedLock) error {
+	encoded, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", lockFilePath, err)
+	}
+	return writeFile(filepath.Join(repoPath, filepath.FromSlash(lockFilePath)), string(encoded)+"\n", 0o644)
+}
+
+func readLock(source repoSource) (seedLock, error) {
+	content, err := source.readFile(lockFilePath)
+	if err != nil {
+		return seedLock{}, err
+	}
+	var lock seedLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return seedLock{}, fmt.Errorf("parse %s: %w", lockFilePath, err)
+	}
+	if lock.Files == nil {
+		lock.Files = map[string]string{}
+	}
+	return lock, nil
+}
+
+// recordLockEntry updates one entry after Seed rewrites a generated file.
+// Repos without a lock are left alone; seed verify --update creates one.
+func recordLockEntry(repoPath, path, content string) error {
+	lock, err := readLock(worktreeSource{root: repoPath})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	lock.Files[path] = contentHash(content)
+	lock.GeneratedBy = cliVersion
+	return saveLock(repoPath, lock)
+}
+
+// removeLockEntry drops a generated file Seed deleted from an existing lock.
+func removeLockEntry(repoPath, path string) error {
+	lock, err := readLock(worktreeSource{root: repoPath})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, ok := lock.Files[path]; !ok {
+		return nil
+	}
+	delete(lock.Files, path)
+	lock.GeneratedBy = cliVersion
+	return saveLock(repoPath, lock)
+}
+
+// checkValidatorLock mirrors the block_validator_edits check in seed-test.sh. The lock
+// and setting in the last commit win over the staged ones, so a commit cannot edit a
+// validator and re-record its hash, or switch the check off, at the same time.
+func checkValidatorLock(source repoSource, rules manifestSnapshot, opts contractOptions, report *layoutReport) {
+	if !opts.preCommit {
+		return
+	}
+	block, lockSource, lockLabel := rules.Integrity.BlockValidatorEdits, source, lockFilePath
+	if opts.committed != nil {
+		if committed, found, err := loadRepoRules(opts.committed, rules.ActiveProfile); err == nil && found && committed.Integrity.BlockValidatorEdits {
+			block = true
+		}
+		if opts.committed.isFile(lockFilePath) {
+			lockSource, lockLabel = opts.committed, "HEAD:"+lockFilePath
+		}
+	}
+	if !block {
+		return
+	}
+	lock, err := readLock(lockSource)
+	if err != nil {
+		report.addError(reasonValidatorChanged, lockFilePath,
+			"Seed lock file %s is missing or unreadable; run seed verify --update to record one", lockFilePath)
+		return
+	}
+	for _, script := range guardedScripts {
+		content, err := source.readFile(script.path)
+		if err != nil {
+			continue
+		}
+		if lock.Files[script.path] != contentHash(string(content)) {
+			report.addError(reasonValidatorChanged, script.path,
+				"Generated validator %s does not match %s; restore it, or commit a reviewed edit with git commit --no-verify", script.path, lockLabel)
+		}
+	}
+}
+
+func parseVerifyArgs(opts options, args []string) (options, error) {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		opts.verify.repoPath = args[0]
+		args = args[1:]
+	}
+	for _, arg := range args {
+		switch arg {
+		case "--update":
+			opts.verify.update = true
+		case "--json":
+			opts.verify.jsonOutput = true
+		case "-h", "--help":
+			opts.showHelp = true
+		default:
+			return opts, fmt.Errorf("unknown argument: %s", arg)
+		}
+	}
+	return opts, nil
+}
+
+func printVerifyUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: seed verify [repo-path] [--update] [--json]")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Compare generated Seed artifacts under .seed/ and skills/seed-validate/ with %s.\n", lockFilePath)
+	fmt.Fprintln(w, "Each file is reported as unchanged, modified, missing, or extra.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	fmt.Fprintln(w, "  --update  Record the current files as the new lock")
+	fmt.Fprintln(w, "  --json    Print a JSON report")
+}
+
+// runVerify returns 1 when any generated file differs from the lock.
+func runVerify(opts verifyOptions, out io.Writer) (int, error) {
+	if opts.update {
+		lock, err := writeLockFile(opts.repoPath)
+		if err != nil {
+			return 1, err
+		}
+		fmt.Fprintf(out, "Recorded %d files in %s\n", len(lock.Files), lockFilePath)
+		return 0, nil
+	}
+
+	lock, err := readLock(worktreeSource{root: opts.repoPath})
+	if errors.Is(err, fs.ErrNotExist) {
+		return 1, fmt.Errorf("%s has no %s; run seed verify --update to record the current files", opts.repoPath, lockFilePath)
+	}
+	if err != nil {
+		return 1, err
+	}
+	report, err := verifyLock(opts.repoPath, lock)
+	if err != nil {
+		return 1, err
+	}
+	code := 0
+	if report.Summary[lockUnchanged] != len(report.Files) {
+		code = 1
+	}
+
+	if opts.jsonOutput {
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return 1, fmt.Errorf("marshal verify report: %w", err)
+		}
+		fmt.Fprintln(out, string(encoded))
+		return code, nil
+	}
+	for _, file := range report.Files {
+		fmt.Fprintf(out, "%-9s  %s\n", file.State, file.Path)
+	}
+	fmt.Fprintf(out, "\n%d unchanged, %d modified, %d missing, %d extra\n",
+		report.Summary[lockUnchanged], report.Summary[lockModified], report.Summary[lockMissing], report.Summary[lockExtra])
+	return code, nil
+}
+
+func verifyLock(repoPath string, lock seedLock) (verifyReport, error) {
+	current, err := lockedFiles(repoPath)
+	if err != nil {
+		return verifyReport{}, err
+	}
+	paths := make([]string, 0, len(lock.Files)+len(current))
+	for path := range lock.Files {
+		paths = append(paths, path)
+	}
+	for _, path := range current {
+		if _, ok := lock.Files[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	report := verifyReport{Lock: lockFilePath, Files: make([]lockFileState, 0, len(paths)), Summary: map[string]int{}}
+	for _, path := range paths {
+		state := lockFileState{Path: path, Expected: lock.Files[path]}
+		content, err := os.ReadFile(filepath.Join(repoPath, filepath.FromSlash(path)))
+		switch {
+		case err != nil:
+			state.State = lockMissing
+		case state.Expected == "":
+			state.State = lockExtra
+			state.Actual = contentHash(string(content))
+		default:
+			state.Actual = contentHash(string(content))
+			state.State = lockUnchanged
+			if state.Actual != state.Expected {
+				state.State = lockModified
+			}
+		}
+		report.Files = append(report.Files, state)
+		report.Summary[state.State]++
+	}
+	return report, nil
+}
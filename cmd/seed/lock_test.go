@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyLock(t *testing.T) {
+	requireGit(t)
+
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "guarded")
+	mustScaffoldGitRepo(t, target, profileGuarded, manifest)
+
+	var out bytes.Buffer
+	if code, err := runVerify(verifyOptions{repoPath: target}, &out); err != nil || code != 0 {
+		t.Fatalf("fresh scaffold should verify, got %d %v\n%s", code, err, out.String())
+	}
+	if !strings.Contains(out.String(), "8 unchanged, 0 modified, 0 missing, 0 extra") {
+		t.Fatalf("unexpected verify output:\n%s", out.String())
+	}
+
+	mustRewriteFile(t, filepath.Join(target, "skills", "seed-validate", "SKILL.md"), func(content string) string {
+		return content + "\nSkip the checks.\n"
+	})
+	if err := os.Remove(filepath.Join(target, ".seed", "rules.tsv")); err != nil {
+		t.Fatalf("remove rules.tsv: %v", err)
+	}
+	if err := writeFile(filepath.Join(target, ".seed", "notes.txt"), "scratch\n", 0o644); err != nil {
+		t.Fatalf("write extra file: %v", err)
+	}
+	out.Reset()
+	code, err := runVerify(verifyOptions{repoPath: target, jsonOutput: true}, &out)
+	if err != nil || code != 1 {
+		t.Fatalf("expected exit 1, got %d %v\n%s", code, err, out.String())
+	}
+	var report verifyReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("parse verify JSON: %v\n%s", err, out.String())
+	}
+	states := make(map[string]string)
+	for _, file := range report.Files {
+		states[file.Path] = file.State
+	}
+	for path, want := range map[string]string{
+		"skills/seed-validate/SKILL.md": lockModified,
+		".seed/rules.tsv":               lockMissing,
+		".seed/notes.txt":               lockExtra,
+		".seed/seed-test.sh":            lockUnchanged,
+	} {
+		if states[path] != want {
+			t.Fatalf("%s: expected %s, got %q\n%s", path, want, states[path], out.String())
+		}
+	}
+
+	// Files Seed rewrites keep the lock current; accepting other edits needs --update.
+	out.Reset()
+	if err := runRefreshScripts(refreshOptions{repoPath: target}, nil, &out); err != nil {
+		t.Fatalf("refresh scripts: %v\n%s", err, out.String())
+	}
+	if report, err := verifyLock(target, mustReadLock(t, target)); err != nil || report.Summary[lockMissing] != 0 || report.Summary[lockModified] != 1 {
+		t.Fatalf("refresh should re-lock rules.tsv only: %+v %v", report.Summary, err)
+	}
+	out.Reset()
+	if code, err := runVerify(verifyOptions{repoPath: target, update: true}, &out); err != nil || code != 0 {
+		t.Fatalf("verify --update: %d %v", code, err)
+	}
+	out.Reset()
+	if code, err := runVerify(verifyOptions{repoPath: target}, &out); err != nil || code != 0 {
+		t.Fatalf("updated lock should verify, got %d %v\n%s", code, err, out.String())
+	}
+
+	// Repo overrides are user-authored and never locked.
+	if err := writeFile(filepath.Join(target, overridesPath), "{\"exempt_files\": [\"docs/**\"]}\n", 0o644); err != nil {
+		t.Fatalf("write overrides: %v", err)
+	}
+	out.Reset()
+	if code, err := runVerify(verifyOptions{repoPath: target}, &out); err != nil || code != 0 || strings.Contains(out.String(), overridesPath) {
+		t.Fatalf("overrides should not be locked, got %d %v\n%s", code, err, out.String())
+	}
+	if err := os.Remove(filepath.Join(target, overridesPath)); err != nil {
+		t.Fatalf("remove overrides: %v", err)
+	}
+
+	// Opting in to block_validator_edits makes pre-commit refuse hand-edited scripts in both validators.
+	manifestPath := filepath.Join(target, ".seed", "manifest.json")
+	mustRewriteFile(t, manifestPath, func(content string) string {
+		return strings.Replace(content, `"block_validator_edits": false`, `"block_validator_edits": true`, 1)
+	})
+	if err := writeRulesFile(target, []byte(mustReadFile(t, manifestPath))); err != nil {
+		t.Fatalf("regenerate rules.tsv: %v", err)
+	}
+	hookPath := filepath.Join(target, ".seed", "hooks", "pre-commit")
+	originalHook := mustReadFile(t, hookPath)
+	mustRewriteFile(t, hookPath, func(content string) string {
+		return content + "exit 0\n"
+	})
+	shellOutput, _ := runCommandWithExit(t, exec.Command("sh", filepath.Join(target, ".seed", "seed-test.sh"), "--pre-commit"))
+	if !strings.Contains(shellOutput, "Generated validator .seed/hooks/pre-commit does not match .seed/lock.json") ||
+		!strings.Contains(parseSeedStatus(shellOutput)["SEED_TRIGGER_REASONS"], reasonValidatorChanged) {
+		t.Fatalf("seed-test.sh should block the edited hook:\n%s", shellOutput)
+	}
+	source := worktreeSource{root: target}
+	rules, _, err := loadRepoRules(source, profileGuarded)
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+	goReport := validateContract(source, rules, contractOptions{today: time.Now(), preCommit: true})
+	if !containsString(goReport.triggerReasons(), reasonValidatorChanged) {
+		t.Fatalf("Go rules should block the edited hook: %+v", goReport)
+	}
+	goReport = validateContract(source, rules, contractOptions{today: time.Now()})
+	if containsString(goReport.triggerReasons(), reasonValidatorChanged) {
+		t.Fatalf("validator edits only block pre-commit: %+v", goReport)
+	}
+
+	// Once committed, the lock in HEAD is trusted: re-recording the edited hook, or
+	// switching the check off, in the same commit still blocks it.
+	mustRewriteFile(t, hookPath, func(string) string { return originalHook })
+	mustCommitAll(t, target, "seed")
+	mustRewriteFile(t, hookPath, func(content string) string {
+		return content + "exit 0\n"
+	})
+	mustRewriteFile(t, manifestPath, func(content string) string {
+		return strings.Replace(content, `"block_validator_edits": true`, `"block_validator_edits": false`, 1)
+	})
+	if err := writeRulesFile(target, []byte(mustReadFile(t, manifestPath))); err != nil {
+		t.Fatalf("regenerate rules.tsv: %v", err)
+	}
+	if code, err := runVerify(verifyOptions{repoPath: target, update: true}, io.Discard); err != nil || code != 0 {
+		t.Fatalf("verify --update: %d %v", code, err)
+	}
+	runCommandMustSucceed(t, exec.Command("git", "-C", target, "add", "-A"))
+	shellOutput, _ = runCommandWithExit(t, exec.Command("sh", filepath.Join(target, ".seed", "seed-test.sh"), "--pre-commit", "--staged"))
+	if !strings.Contains(shellOutput, "Generated validator .seed/hooks/pre-commit does not match HEAD:.seed/lock.json") {
+		t.Fatalf("seed-test.sh should trust the committed lock:\n%s", shellOutput)
+	}
+	head, err := newGitRevisionSource(target, "HEAD")
+	if err != nil {
+		t.Fatalf("read HEAD: %v", err)
+	}
+	if rules, _, err = loadRepoRules(source, profileGuarded); err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+	goReport = validateContract(source, rules, contractOptions{today: time.Now(), preCommit: true, committed: head})
+	if !containsString(goReport.triggerReasons(), reasonValidatorChanged) {
+		t.Fatalf("Go rules should trust the committed lock: %+v", goReport)
+	}
+}
+
+func mustReadLock(t *testing.T, repoPath string) seedLock {
+	t.Helper()
+	lock, err := readLock(worktreeSource{root: repoPath})
+	if err != nil {
+		t.Fatalf("read lock: %v", err)
+	}
+	return lock
+}
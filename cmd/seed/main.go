@@ -2,15 +2,19 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	seedassets "seed"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 	"unicode"
 )
@@ -48,36 +52,82 @@ type options struct {
 	showHelp   bool
 	install    installOptions
 	validate   validateLayoutOptions
+	docList    docListOptions
+	hooks      hooksOptions
+	refresh    refreshOptions
+	fleet      fleetOptions
+	verify     verifyOptions
+	diff       diffOptions
+	sync       syncOptions
+	migrate    migrateOptions
+	manifest   manifestOptions
 }
 
 type canonicalManifest struct {
 	// Canonical contract loaded from embedded assets.
 	SeedFormatVersion string                  `json:"seed_format_version"`
 	DefaultProfile    string                  `json:"default_profile"`
+	ProfileOrder      []string                `json:"profile_order"`
 	Profiles          map[string]profileRules `json:"profiles"`
 }
 
 type profileRules struct {
-	Description            string   `json:"description"`
-	ValidationMode         string   `json:"validation_mode"`
-	ValidationEntrypoint   string   `json:"validation_entrypoint,omitempty"`
-	WarningsAsErrors       bool     `json:"warnings_as_errors"`
-	RequiredFiles          []string `json:"required_files"`
-	RequiredHeadings       []string `json:"required_headings"`
-	HeadingAliases         []string `json:"heading_aliases"`
-	MisplacedContentSignal []string `json:"misplaced_content_signals"`
+	Description             string               `json:"description"`
+	ValidationMode          string               `json:"validation_mode"`
+	ValidationEntrypoint    string               `json:"validation_entrypoint,omitempty"`
+	WarningsAsErrors        bool                 `json:"warnings_as_errors"`
+	RequiredFiles           []string             `json:"required_files"`
+	FileRules               map[string]fileRule  `json:"file_rules,omitempty"`
+	RequiredHeadings        []string             `json:"required_headings"`
+	HeadingAliases          []string             `json:"heading_aliases"`
+	MisplacedContentSignal  []string             `json:"misplaced_content_signals"`
+	MisplacedContentScope   scanScope            `json:"misplaced_content_scope"`
+	MisplacedContentPhrases misplacedPhraseRules `json:"misplaced_content_phrases"`
+	HeadingNearMiss         headingNearMissRules `json:"heading_near_miss"`
+	NonEmptySections        []string             `json:"non_empty_sections"`
+	RequiredListFields      []string             `json:"required_list_fields"`
+	PlaceholderPolicy       placeholderPolicy    `json:"placeholder_policy"`
+	Integrity               integrityRules       `json:"integrity"`
+	DecisionsFile           string               `json:"decisions_file,omitempty"`
+	TodoFile                string               `json:"todo_file,omitempty"`
+	TodoSections            []string             `json:"todo_sections,omitempty"`
+	TodoCheckboxSections    []string             `json:"todo_checkbox_sections,omitempty"`
+	TodoBlockersSection     string               `json:"todo_blockers_section,omitempty"`
+	TodoDoneSection         string               `json:"todo_done_section,omitempty"`
+	TodoDoneLimit           int                  `json:"todo_done_limit,omitempty"`
+	Staleness               stalenessRules       `json:"staleness"`
+	// RuleSeverities maps finding reasons to off, info, warning or error.
+	RuleSeverities map[string]string `json:"rule_severities,omitempty"`
 }
 
 type manifestSnapshot struct {
-	SeedFormatVersion      string   `json:"seed_format_version"`
-	ActiveProfile          string   `json:"active_profile"`
-	ValidationMode         string   `json:"validation_mode"`
-	ValidationEntrypoint   string   `json:"validation_entrypoint,omitempty"`
-	WarningsAsErrors       bool     `json:"warnings_as_errors"`
-	RequiredFiles          []string `json:"required_files"`
-	RequiredHeadings       []string `json:"required_headings"`
-	HeadingAliases         []string `json:"heading_aliases"`
-	MisplacedContentSignal []string `json:"misplaced_content_signals"`
+	SeedFormatVersion       string               `json:"seed_format_version"`
+	ActiveProfile           string               `json:"active_profile"`
+	ValidationMode          string               `json:"validation_mode"`
+	ValidationEntrypoint    string               `json:"validation_entrypoint,omitempty"`
+	WarningsAsErrors        bool                 `json:"warnings_as_errors"`
+	RequiredFiles           []string             `json:"required_files"`
+	FileRules               map[string]fileRule  `json:"file_rules,omitempty"`
+	RequiredHeadings        []string             `json:"required_headings"`
+	HeadingAliases          []string             `json:"heading_aliases"`
+	MisplacedContentSignal  []string             `json:"misplaced_content_signals"`
+	MisplacedContentScope   scanScope            `json:"misplaced_content_scope"`
+	MisplacedContentPhrases misplacedPhraseRules `json:"misplaced_content_phrases"`
+	HeadingNearMiss         headingNearMissRules `json:"heading_near_miss"`
+	NonEmptySections        []string             `json:"non_empty_sections"`
+	RequiredListFields      []string             `json:"required_list_fields"`
+	PlaceholderPolicy       placeholderPolicy    `json:"placeholder_policy"`
+	Integrity               integrityRules       `json:"integrity"`
+	DecisionsFile           string               `json:"decisions_file,omitempty"`
+	TodoFile                string               `json:"todo_file,omitempty"`
+	TodoSections            []string             `json:"todo_sections,omitempty"`
+	TodoCheckboxSections    []string             `json:"todo_checkbox_sections,omitempty"`
+	TodoBlockersSection     string               `json:"todo_blockers_section,omitempty"`
+	TodoDoneSection         string               `json:"todo_done_section,omitempty"`
+	TodoDoneLimit           int                  `json:"todo_done_limit,omitempty"`
+	Staleness               stalenessRules       `json:"staleness"`
+	// RuleSeverities maps finding reasons to off, info, warning or error.
+	RuleSeverities map[string]string `json:"rule_severities,omitempty"`
 }
 
 type scaffoldInput struct {
@@ -93,6 +143,8 @@ type scaffoldInput struct {
 	SeedProfile       string
 	CreatedDate       string
 	GeneratedFromSeed string
+	// Placeholders lists ids of starter text that renderers tag with seed:placeholder markers.
+	Placeholders map[string]bool
 }
 
 func main() {
@@ -104,6 +156,26 @@ func main() {
 			printInstallUsage(os.Stderr)
 		case commandValidate:
 			printValidateLayoutUsage(os.Stderr)
+		case commandDecisions:
+			printDecisionsUsage(os.Stderr)
+		case commandTodo:
+			printTodoUsage(os.Stderr)
+		case commandHooks:
+			printHooksUsage(os.Stderr)
+		case commandRefreshScripts:
+			printRefreshScriptsUsage(os.Stderr)
+		case commandFleet:
+			printFleetUsage(os.Stderr)
+		case commandVerify:
+			printVerifyUsage(os.Stderr)
+		case commandDiff:
+			printDiffUsage(os.Stderr)
+		case commandSync:
+			printSyncUsage(os.Stderr)
+		case commandMigrate:
+			printMigrateUsage(os.Stderr)
+		case commandManifest:
+			printManifestUsage(os.Stderr)
 		default:
 			printScaffoldUsage(os.Stderr)
 		}
@@ -115,6 +187,26 @@ func main() {
 			printInstallUsage(os.Stdout)
 		case commandValidate:
 			printValidateLayoutUsage(os.Stdout)
+		case commandDecisions:
+			printDecisionsUsage(os.Stdout)
+		case commandTodo:
+			printTodoUsage(os.Stdout)
+		case commandHooks:
+			printHooksUsage(os.Stdout)
+		case commandRefreshScripts:
+			printRefreshScriptsUsage(os.Stdout)
+		case commandFleet:
+			printFleetUsage(os.Stdout)
+		case commandVerify:
+			printVerifyUsage(os.Stdout)
+		case commandDiff:
+			printDiffUsage(os.Stdout)
+		case commandSync:
+			printSyncUsage(os.Stdout)
+		case commandMigrate:
+			printMigrateUsage(os.Stdout)
+		case commandManifest:
+			printManifestUsage(os.Stdout)
 		default:
 			printUsage(os.Stdout)
 		}
@@ -130,37 +222,121 @@ func main() {
 		return
 	}
 
+	if opts.command == commandValidate && opts.validate.watch {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		cfg := watchConfig{interval: defaultWatchInterval, debounce: defaultWatchDebounce, relist: defaultWatchRelist, color: isInteractive(os.Stdout)}
+		exitCode := runValidateWatch(ctx, opts.validate, cfg, os.Stdout, os.Stderr)
+		stop()
+		os.Exit(exitCode)
+	}
+
 	if opts.command == commandValidate {
 		exitCode := runValidateLayout(opts.validate, os.Stdout, os.Stderr)
 		os.Exit(exitCode)
 	}
 
+	if opts.command == commandDecisions {
+		if err := runDecisionsList(opts.docList, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if opts.command == commandTodo {
+		if err := runTodoList(opts.docList, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if opts.command == commandHooks {
+		if err := runHooks(opts.hooks, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if opts.command == commandRefreshScripts {
+		var confirm io.Reader
+		if isInteractive(os.Stdin) && isInteractive(os.Stdout) {
+			confirm = os.Stdin
+		}
+		if err := runRefreshScripts(opts.refresh, confirm, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if opts.command == commandMigrate {
+		if err := runMigrate(opts.migrate, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if opts.command == commandManifest {
+		exitCode, err := runManifest(opts.manifest, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		}
+		os.Exit(exitCode)
+	}
+
+	if opts.command == commandSync {
+		exitCode, err := runSync(opts.sync, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		}
+		os.Exit(exitCode)
+	}
+
+	if opts.command == commandDiff {
+		exitCode, err := runDiff(opts.diff, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		}
+		os.Exit(exitCode)
+	}
+
+	if opts.command == commandVerify {
+		exitCode, err := runVerify(opts.verify, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		}
+		os.Exit(exitCode)
+	}
+
+	if opts.command == commandFleet {
+		exitCode, err := runFleet(opts.fleet, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		}
+		os.Exit(exitCode)
+	}
+
+	manifest, err := loadCanonicalManifest()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+
 	profile := opts.profile
 	interactive := isInteractive(os.Stdin) && isInteractive(os.Stdout)
 	if !opts.profileSet {
 		if interactive {
-			selected, selectErr := chooseProfile(os.Stdin, os.Stdout)
+			selected, selectErr := chooseProfile(os.Stdin, os.Stdout, manifest)
 			if selectErr != nil {
 				fmt.Fprintf(os.Stderr, "Error: %s\n", selectErr)
 				os.Exit(1)
 			}
 			profile = selected
 		} else {
-			profile = profileLLM
-		}
-	}
-
-	manifest, err := loadCanonicalManifest()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		os.Exit(1)
-	}
-
-	if profile == "" {
-		if manifest.DefaultProfile != "" {
 			profile = manifest.DefaultProfile
-		} else {
-			profile = profileLLM
 		}
 	}
 
@@ -194,6 +370,27 @@ func parseArgs(args []string) (options, error) {
 		validate: validateLayoutOptions{
 			repoPath: ".",
 		},
+		docList: docListOptions{
+			repoPath: ".",
+		},
+		hooks: hooksOptions{
+			repoPath: ".",
+		},
+		refresh: refreshOptions{
+			repoPath: ".",
+		},
+		verify: verifyOptions{
+			repoPath: ".",
+		},
+		diff: diffOptions{
+			repoPath: ".",
+		},
+		sync: syncOptions{
+			repoPath: ".",
+		},
+		migrate: migrateOptions{
+			repoPath: ".",
+		},
 	}
 
 	if len(args) > 0 {
@@ -204,6 +401,36 @@ func parseArgs(args []string) (options, error) {
 		case commandValidate:
 			opts.command = commandValidate
 			return parseValidateLayoutArgs(opts, args[1:])
+		case commandDecisions:
+			opts.command = commandDecisions
+			return parseDecisionsArgs(opts, args[1:])
+		case commandTodo:
+			opts.command = commandTodo
+			return parseTodoArgs(opts, args[1:])
+		case commandHooks:
+			opts.command = commandHooks
+			return parseHooksArgs(opts, args[1:])
+		case commandRefreshScripts:
+			opts.command = commandRefreshScripts
+			return parseRefreshScriptsArgs(opts, args[1:])
+		case commandFleet:
+			opts.command = commandFleet
+			return parseFleetArgs(opts, args[1:])
+		case commandVerify:
+			opts.command = commandVerify
+			return parseVerifyArgs(opts, args[1:])
+		case commandDiff:
+			opts.command = commandDiff
+			return parseDiffArgs(opts, args[1:])
+		case commandSync:
+			opts.command = commandSync
+			return parseSyncArgs(opts, args[1:])
+		case commandMigrate:
+			opts.command = commandMigrate
+			return parseMigrateArgs(opts, args[1:])
+		case commandManifest:
+			opts.command = commandManifest
+			return parseManifestArgs(opts, args[1:])
 		}
 	}
 
@@ -253,6 +480,26 @@ func printUsage(w io.Writer) {
 	printInstallUsage(w)
 	fmt.Fprintln(w)
 	printValidateLayoutUsage(w)
+	fmt.Fprintln(w)
+	printDecisionsUsage(w)
+	fmt.Fprintln(w)
+	printTodoUsage(w)
+	fmt.Fprintln(w)
+	printHooksUsage(w)
+	fmt.Fprintln(w)
+	printRefreshScriptsUsage(w)
+	fmt.Fprintln(w)
+	printFleetUsage(w)
+	fmt.Fprintln(w)
+	printVerifyUsage(w)
+	fmt.Fprintln(w)
+	printDiffUsage(w)
+	fmt.Fprintln(w)
+	printSyncUsage(w)
+	fmt.Fprintln(w)
+	printMigrateUsage(w)
+	fmt.Fprintln(w)
+	printManifestUsage(w)
 }
 
 func printScaffoldUsage(w io.Writer) {
@@ -275,35 +522,40 @@ func printScaffoldUsage(w io.Writer) {
 	fmt.Fprintln(w, "  - If --profile is omitted, Seed defaults to llm.")
 }
 
-func chooseProfile(in io.Reader, out io.Writer) (string, error) {
+// profileMenuLabels are the short prompt descriptions; manifest profile_order sets the numbering.
+var profileMenuLabels = map[string]string{
+	profileCore:    "core markdown files only",
+	profileLLM:     "core + manifest + local seed-validate skill",
+	profileGuarded: "llm + seed-test + pre-commit hooks",
+}
+
+func chooseProfile(in io.Reader, out io.Writer, manifest canonicalManifest) (string, error) {
 	reader := bufio.NewReader(in)
+	defaultChoice := 1
 	fmt.Fprintln(out, "Choose a Seed profile:")
-	fmt.Fprintln(out, "  1) core    - core markdown files only")
-	fmt.Fprintln(out, "  2) llm     - core + manifest + local seed-validate skill")
-	fmt.Fprintln(out, "  3) guarded - llm + seed-test + pre-commit hooks")
+	for i, profile := range manifest.ProfileOrder {
+		fmt.Fprintf(out, "  %d) %-7s - %s\n", i+1, profile, profileMenuLabels[profile])
+		if profile == manifest.DefaultProfile {
+			defaultChoice = i + 1
+		}
+	}
 
 	for {
-		fmt.Fprint(out, "Select profile [2]: ")
+		fmt.Fprintf(out, "Select profile [%d]: ", defaultChoice)
 		line, err := reader.ReadString('\n')
 		if err != nil && !errors.Is(err, io.EOF) {
 			return "", err
 		}
 		choice := strings.TrimSpace(line)
 		if choice == "" {
-			return profileLLM, nil
-		}
-		switch choice {
-		case "1":
-			return profileCore, nil
-		case "2":
-			return profileLLM, nil
-		case "3":
-			return profileGuarded, nil
-		default:
-			fmt.Fprintln(out, "Invalid choice. Enter 1, 2, or 3.")
+			return manifest.DefaultProfile, nil
+		}
+		if index, convErr := strconv.Atoi(choice); convErr == nil && index >= 1 && index <= len(manifest.ProfileOrder) {
+			return manifest.ProfileOrder[index-1], nil
 		}
+		fmt.Fprintf(out, "Invalid choice. Enter a number from 1 to %d.\n", len(manifest.ProfileOrder))
 		if errors.Is(err, io.EOF) {
-			return profileLLM, nil
+			return manifest.DefaultProfile, nil
 		}
 	}
 }
@@ -323,11 +575,8 @@ func loadCanonicalManifest() (canonicalManifest, error) {
 	}
 
 	var manifest canonicalManifest
-	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
-		return canonicalManifest{}, fmt.Errorf("parse canonical manifest: %w", err)
-	}
-	if len(manifest.Profiles) == 0 {
-		return canonicalManifest{}, errors.New("canonical manifest has no profile definitions")
+	if err := decodeManifest(manifestBytes, canonicalSchemaPath, &manifest); err != nil {
+		return canonicalManifest{}, fmt.Errorf("invalid canonical manifest: %w", err)
 	}
 	return manifest, nil
 }
@@ -358,6 +607,15 @@ func defaultScaffoldInput(targetDir, profile string) (scaffoldInput, error) {
 		SeedProfile:       profile,
 		CreatedDate:       today,
 		GeneratedFromSeed: "Generated by Seed CLI.",
+		Placeholders: map[string]bool{
+			placeholderOneLiner:         true,
+			placeholderProblemStatement: true,
+			placeholderSuccessCriteria:  true,
+			placeholderRunCommand:       true,
+			placeholderTimeline:         true,
+			placeholderBudget:           true,
+			placeholderMustWorkWith:     true,
+		},
 	}, nil
 }
 
@@ -388,28 +646,18 @@ func scaffold(targetDir, profile string, in scaffoldInput, manifest canonicalMan
 		return err
 	}
 
-	if err := writeFile(filepath.Join(targetDir, "README.md"), renderReadme(in, profile), 0o644); err != nil {
-		return err
-	}
-	if err := writeFile(filepath.Join(targetDir, "DECISIONS.md"), renderDecisions(in), 0o644); err != nil {
-		return err
-	}
-	if err := writeFile(filepath.Join(targetDir, "TODO.md"), renderTODO(), 0o644); err != nil {
-		return err
-	}
-	if err := writeFile(filepath.Join(targetDir, "CONTEXT.md"), renderContext(in, profile), 0o644); err != nil {
+	rules, err := manifestForProfile(manifest, profile)
+	if err != nil {
 		return err
 	}
-	if err := writeFile(filepath.Join(targetDir, "AGENTS.md"), renderAgents(profile), 0o644); err != nil {
-		return err
+	for _, doc := range seedDocs {
+		if err := writeFile(filepath.Join(targetDir, doc), renderSeedDoc(doc, in, rules), 0o644); err != nil {
+			return err
+		}
 	}
 
 	if profile != profileCore {
-		snapshot, err := manifestForProfile(manifest, profile)
-		if err != nil {
-			return err
-		}
-		encoded, err := json.MarshalIndent(snapshot, "", "  ")
+		encoded, err := json.MarshalIndent(rules, "", "  ")
 		if err != nil {
 			return fmt.Errorf("marshal profile manifest: %w", err)
 		}
@@ -427,15 +675,29 @@ func scaffold(targetDir, profile string, in scaffoldInput, manifest canonicalMan
 	}
 
 	if profile == profileGuarded {
-		if err := writeFile(filepath.Join(targetDir, ".seed", "seed-test.sh"), guardedSeedTestScript, 0o755); err != nil {
+		manifestBytes, err := os.ReadFile(filepath.Join(targetDir, ".seed", "manifest.json"))
+		if err != nil {
+			return fmt.Errorf("read .seed/manifest.json: %w", err)
+		}
+		if err := writeRulesFile(targetDir, manifestBytes); err != nil {
 			return err
 		}
-		if err := writeFile(filepath.Join(targetDir, ".seed", "hooks", "pre-commit"), guardedPreCommitHookScript, 0o755); err != nil {
+		for _, script := range guardedScripts {
+			if err := writeGuardedScript(targetDir, script, manifest.SeedFormatVersion); err != nil {
+				return err
+			}
+		}
+	}
+	if profile != profileCore {
+		if err := writeManagedBase(targetDir); err != nil {
 			return err
 		}
-		if err := writeFile(filepath.Join(targetDir, ".seed", "install-hooks.sh"), guardedInstallHooksScript, 0o755); err != nil {
+		if _, err := writeLockFile(targetDir); err != nil {
 			return err
 		}
+	}
+	// Hooks go last so a failed install leaves every generated file and the lock in place.
+	if profile == profileGuarded {
 		if err := runGuardedHookInstall(targetDir); err != nil {
 			return err
 		}
@@ -446,12 +708,12 @@ func scaffold(targetDir, profile string, in scaffoldInput, manifest canonicalMan
 	fmt.Fprintln(out)
 	fmt.Fprintln(out, "Next steps:")
 	fmt.Fprintf(out, "1. cd %s\n", targetDir)
-	fmt.Fprintln(out, "2. Replace placeholder run command in README.md")
+	fmt.Fprintln(out, "2. Replace starter text marked seed:placeholder in README.md and CONTEXT.md")
 	if profile == profileLLM {
 		fmt.Fprintln(out, "3. Use skills/seed-validate/SKILL.md when making large doc or structure changes")
 	}
 	if profile == profileGuarded {
-		fmt.Fprintln(out, "3. Pre-commit hooks are active and run ./.seed/seed-test.sh")
+		fmt.Fprintln(out, "3. Pre-commit and pre-push hooks are active and run ./.seed/seed-test.sh")
 		fmt.Fprintln(out, "4. If warnings appear, run skills/seed-validate/SKILL.md")
 	}
 	if profile == profileCore {
@@ -505,15 +767,32 @@ func manifestForProfile(manifest canonicalManifest, profile string) (manifestSna
 		return manifestSnapshot{}, fmt.Errorf("canonical manifest missing profile rules for %s", profile)
 	}
 	return manifestSnapshot{
-		SeedFormatVersion:      manifest.SeedFormatVersion,
-		ActiveProfile:          profile,
-		ValidationMode:         rules.ValidationMode,
-		ValidationEntrypoint:   rules.ValidationEntrypoint,
-		WarningsAsErrors:       rules.WarningsAsErrors,
-		RequiredFiles:          rules.RequiredFiles,
-		RequiredHeadings:       rules.RequiredHeadings,
-		HeadingAliases:         rules.HeadingAliases,
-		MisplacedContentSignal: rules.MisplacedContentSignal,
+		SeedFormatVersion:       manifest.SeedFormatVersion,
+		ActiveProfile:           profile,
+		ValidationMode:          rules.ValidationMode,
+		ValidationEntrypoint:    rules.ValidationEntrypoint,
+		WarningsAsErrors:        rules.WarningsAsErrors,
+		RequiredFiles:           rules.RequiredFiles,
+		FileRules:               rules.FileRules,
+		RequiredHeadings:        rules.RequiredHeadings,
+		HeadingAliases:          rules.HeadingAliases,
+		MisplacedContentSignal:  rules.MisplacedContentSignal,
+		MisplacedContentScope:   rules.MisplacedContentScope,
+		MisplacedContentPhrases: rules.MisplacedContentPhrases,
+		HeadingNearMiss:         rules.HeadingNearMiss,
+		NonEmptySections:        rules.NonEmptySections,
+		RequiredListFields:      rules.RequiredListFields,
+		PlaceholderPolicy:       rules.PlaceholderPolicy,
+		Integrity:               rules.Integrity,
+		DecisionsFile:           rules.DecisionsFile,
+		TodoFile:                rules.TodoFile,
+		TodoSections:            rules.TodoSections,
+		TodoCheckboxSections:    rules.TodoCheckboxSections,
+		TodoBlockersSection:     rules.TodoBlockersSection,
+		TodoDoneSection:         rules.TodoDoneSection,
+		TodoDoneLimit:           rules.TodoDoneLimit,
+		Staleness:               rules.Staleness,
+		RuleSeverities:          rules.RuleSeverities,
 	}, nil
 }
 
@@ -528,12 +807,23 @@ func runGuardedHookInstall(targetDir string) error {
 		return fmt.Errorf("guarded profile requires an initialized git repo in %s. run: (cd %s && git init && ./.seed/install-hooks.sh)", targetDir, targetDir)
 	}
 
-	// Guarded hook setup runs via a generated script so seeded repos work without Seed installed.
-	install := exec.Command("sh", "./.seed/install-hooks.sh")
-	install.Dir = targetDir
-	if output, err := install.CombinedOutput(); err != nil {
-		return fmt.Errorf("guarded profile created files but hook setup failed: %s", strings.TrimSpace(string(output)))
+	if err := runInstallHooksScript(targetDir, false); err != nil {
+		return fmt.Errorf("guarded profile created files but %w", err)
 	}
 
 	return nil
 }
+
+// runInstallHooksScript runs the generated installer so seeded repos work without Seed installed.
+func runInstallHooksScript(repoPath string, force bool) error {
+	args := []string{"./.seed/install-hooks.sh"}
+	if force {
+		args = append(args, "--force")
+	}
+	install := exec.Command("sh", args...)
+	install.Dir = repoPath
+	if output, err := install.CombinedOutput(); err != nil {
+		return fmt.Errorf("hook setup failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
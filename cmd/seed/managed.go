@@ -0,0 +1,392 @@
+package main
+
+// Managed blocks mark the Seed-owned parts of generated docs so they can be regenerated safely.
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const commandSync = "sync"
+
+// managedBasePath keeps the last generated body of each block as the merge base for conflicts.
+// Core repos have no .seed/ directory; their bases are found in git history instead.
+const managedBasePath = ".seed/managed.json"
+
+var (
+	managedBeginPattern = regexp.MustCompile(`^<!-- seed:managed id=([a-z0-9-]+) hash=([0-9a-f]{64}) -->$`)
+	managedEndPattern   = regexp.MustCompile(`^<!-- /seed:managed id=([a-z0-9-]+) -->$`)
+)
+
+const (
+	blockCurrent   = "current"
+	blockUpdated   = "updated"
+	blockEdited    = "edited"
+	blockConflict  = "conflict"
+	blockUnmanaged = "unmanaged"
+	blockAdopted   = "adopted"
+)
+
+// unmarkedBlockHashes are the managedHash of every section body Seed generated before docs
+// carried managed markers, so sync can adopt sections from those releases that were never edited.
+var unmarkedBlockHashes = map[string][]string{
+	"agents-working-rules": {
+		"637a3319c191e08b575694d5d86a2d320ae15a2cc8133d02d1f26d72e32e0187",
+		"2560385920982beb93db5327482fca4a9bd52ffd2203b9ba03ad1499489a8eb5",
+		"6d0f0fbc4534afceb035dae69e350295a687edc47cd79c0b96358aa9a31464e2",
+		"11307f1454277154c4794e219ebffbea5de70d069295ab1a7ccf3c8099895977",
+		"2871e57a22d1b9680e0c7d64deb312a9be13e3e57d46f4e5670168ea90952fc0",
+	},
+	"agents-poc-guardrails": {
+		"de374ef2f129e413fa71a423d867bd3802e67d8b79f9aa13bcb929457dcd27c5",
+	},
+	"agents-upgrade-triggers": {
+		"57e2dd54c542312abd949edb1169aba4ae057f9a428193722e9281996a0074c6",
+	},
+	"context-poc-philosophy": {
+		"62cf5c5752c1e64e246850c6b343617599e70a983da5015456538a58c5a8051c",
+	},
+	"context-upgrade-triggers": {
+		"fa778640dac30637b899f218ed95ee335a20944fe08f79a9ff94cff23cbef9e8",
+	},
+	"context-key-files": {
+		"b961ff289e455c55c37346fe4ad7e623ef22f43f677f4c40d5ad44c11d1606ff",
+		"56db8cc62c0271e42676ee9f7cd0f9ba831d1117e8550fd2bb1bc96e9297abe2",
+		"f831a47e9a45d0c9fcd8907306d7fbfb55231847515157f5e2840752177c8eac",
+		"c62eb6dc73d75de3a64ee9925881882e4e09fe2ac42b77ab74bbb5c1d4f4767d",
+	},
+	"readme-seed-files": {
+		"31015274985e7977ee0c56c0d3cd1752f8ab357b9ef379bf08feddae08a268bf",
+		"92a66bec23c9782e496b9283b5cd33e6b50e2972723cf5aab7ce0d5790999e67",
+		"e59136d012a3f5a905b82485f18a7d2e12bcb991e455ed96eb6dcf0377674c71",
+		"c57a1aba57347ab0c1f15adf195647de6cd0cd430fffc2e3618795504c2f9265",
+	},
+}
+
+type syncOptions struct {
+	repoPath string
+	dryRun   bool
+}
+
+type managedBlock struct {
+	ID   string
+	Hash string
+	// Body is the text between the markers, one element per line.
+	Body []string
+	// Begin and End are the line indexes of the markers.
+	Begin int
+	End   int
+	// Heading is the section heading line the block sits under.
+	Heading string
+}
+
+type managedBase struct {
+	Blocks map[string]string `json:"blocks"`
+}
+
+// renderManagedBlock wraps Seed-owned text in markers that record the hash of what was generated.
+func renderManagedBlock(id, body string) string {
+	return fmt.Sprintf("<!-- seed:managed id=%s hash=%s -->\n%s\n<!-- /seed:managed id=%s -->", id, managedHash(strings.Split(body, "\n")), body, id)
+}
+
+func managedHash(body []string) string {
+	return contentHash(strings.Join(body, "\n") + "\n")
+}
+
+// parseManagedBlocks returns complete blocks in file order; unterminated markers are ignored.
+func parseManagedBlocks(content string) []managedBlock {
+	lines := strings.Split(content, "\n")
+	blocks := make([]managedBlock, 0)
+	heading := ""
+	for i := 0; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], "#") {
+			heading = lines[i]
+		}
+		match := managedBeginPattern.FindStringSubmatch(lines[i])
+		if match == nil {
+			continue
+		}
+		for j := i + 1; j < len(lines); j++ {
+			if end := managedEndPattern.FindStringSubmatch(lines[j]); end != nil && end[1] == match[1] {
+				blocks = append(blocks, managedBlock{ID: match[1], Hash: match[2], Body: append([]string{}, lines[i+1:j]...), Begin: i, End: j, Heading: heading})
+				i = j
+				break
+			}
+		}
+	}
+	return blocks
+}
+
+// renderedManagedBlocks renders every Seed doc from profile rules and returns its blocks by file.
+func renderedManagedBlocks(in scaffoldInput, rules manifestSnapshot) map[string][]managedBlock {
+	blocks := make(map[string][]managedBlock, len(seedDocs))
+	for _, doc := range seedDocs {
+		blocks[doc] = parseManagedBlocks(renderSeedDoc(doc, in, rules))
+	}
+	return blocks
+}
+
+// adoptSection finds the unmarked section a rendered block belongs in. Only a body that this
+// or an earlier Seed generated is adopted, so sections the user rewrote stay unmanaged.
+func adoptSection(lines []string, next managedBlock) (managedBlock, bool) {
+	for i, line := range lines {
+		if line != next.Heading {
+			continue
+		}
+		begin := i + 1
+		for begin < len(lines) && strings.TrimSpace(lines[begin]) == "" {
+			begin++
+		}
+		end := begin
+		for end < len(lines) && !strings.HasPrefix(lines[end], "#") {
+			end++
+		}
+		for end > begin && strings.TrimSpace(lines[end-1]) == "" {
+			end--
+		}
+		body := lines[begin:end]
+		hash := managedHash(body)
+		if begin == end || (hash != next.Hash && !containsString(unmarkedBlockHashes[next.ID], hash)) {
+			return managedBlock{}, false
+		}
+		return managedBlock{ID: next.ID, Hash: hash, Body: append([]string{}, body...), Begin: begin, End: end - 1}, true
+	}
+	return managedBlock{}, false
+}
+
+// writeManagedBase records the generated body of every unedited block in the repo's docs.
+// Edited blocks keep their previous base so later conflicts can still show it.
+func writeManagedBase(repoPath string) error {
+	base := managedBase{Blocks: map[string]string{}}
+	if content, err := os.ReadFile(filepath.Join(repoPath, filepath.FromSlash(managedBasePath))); err == nil {
+		if err := json.Unmarshal(content, &base); err != nil {
+			return fmt.Errorf("parse %s: %w", managedBasePath, err)
+		}
+		if base.Blocks == nil {
+			base.Blocks = map[string]string{}
+		}
+	}
+	for _, doc := range seedDocs {
+		content, err := os.ReadFile(filepath.Join(repoPath, doc))
+		if err != nil {
+			continue
+		}
+		for _, block := range parseManagedBlocks(string(content)) {
+			if managedHash(block.Body) == block.Hash {
+				base.Blocks[block.ID] = strings.Join(block.Body, "\n")
+			}
+		}
+	}
+	encoded, err := json.MarshalIndent(base, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", managedBasePath, err)
+	}
+	content := string(encoded) + "\n"
+	if err := writeFile(filepath.Join(repoPath, filepath.FromSlash(managedBasePath)), content, 0o644); err != nil {
+		return err
+	}
+	return recordLockEntry(repoPath, managedBasePath, content)
+}
+
+func parseSyncArgs(opts options, args []string) (options, error) {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		opts.sync.repoPath = args[0]
+		args = args[1:]
+	}
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run":
+			opts.sync.dryRun = true
+		case "-h", "--help":
+			opts.showHelp = true
+		default:
+			return opts, fmt.Errorf("unknown argument: %s", arg)
+		}
+	}
+	return opts, nil
+}
+
+func printSyncUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: seed sync [repo-path] [--dry-run]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Regenerate the seed:managed blocks in Seed docs from this CLI.")
+	fmt.Fprintln(w, "Blocks edited since generation are never overwritten; when Seed changed them too,")
+	fmt.Fprintln(w, "a three-way conflict is printed and the command exits 1.")
+	fmt.Fprintln(w, "Unmarked sections whose text an earlier Seed generated are adopted into blocks.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	fmt.Fprintln(w, "  --dry-run  Show diffs without writing")
+}
+
+// runSync rewrites blocks whose recorded hash still matches their text.
+func runSync(opts syncOptions, out io.Writer) (int, error) {
+	source := worktreeSource{root: opts.repoPath}
+	profile, err := inferSeedProfile(source)
+	if err != nil {
+		return 1, err
+	}
+	in, err := defaultScaffoldInput(opts.repoPath, profile)
+	if err != nil {
+		return 1, err
+	}
+	manifest, err := loadCanonicalManifest()
+	if err != nil {
+		return 1, err
+	}
+	rules, err := manifestForProfile(manifest, profile)
+	if err != nil {
+		return 1, err
+	}
+	base := managedBase{Blocks: map[string]string{}}
+	if content, err := source.readFile(managedBasePath); err == nil {
+		if err := json.Unmarshal(content, &base); err != nil {
+			return 1, fmt.Errorf("parse %s: %w", managedBasePath, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return 1, err
+	}
+
+	rendered := renderedManagedBlocks(in, rules)
+	conflicts, managed, changed := 0, 0, false
+	for _, doc := range seedDocs {
+		currentBytes, err := source.readFile(doc)
+		if err != nil || len(rendered[doc]) == 0 {
+			continue
+		}
+		current := string(currentBytes)
+		lines := strings.Split(current, "\n")
+		existing := make(map[string]managedBlock)
+		for _, block := range parseManagedBlocks(current) {
+			existing[block.ID] = block
+		}
+
+		replaced := make([]managedBlock, 0)
+		for _, next := range rendered[doc] {
+			block, ok := existing[next.ID]
+			state := syncBlockState(block, next, ok)
+			if !ok {
+				if block, ok = adoptSection(lines, next); ok {
+					state = blockAdopted
+				}
+			}
+			if state != blockUnmanaged {
+				managed++
+			}
+			fmt.Fprintf(out, "%s: %s %s\n", doc, next.ID, state)
+			switch state {
+			case blockUpdated, blockAdopted:
+				block.Body = next.Body
+				replaced = append(replaced, block)
+			case blockConflict:
+				conflicts++
+				baseBody, hasBase := base.Blocks[next.ID]
+				hasBase = hasBase && managedHash(strings.Split(baseBody, "\n")) == block.Hash
+				if !hasBase {
+					baseBody, hasBase = historyBase(opts.repoPath, doc, block)
+				}
+				fmt.Fprint(out, renderConflict(doc, block.Body, baseBody, hasBase, next.Body))
+			}
+		}
+		// Replace from the bottom up so earlier line indexes stay valid.
+		sort.Slice(replaced, func(i, j int) bool { return replaced[i].Begin > replaced[j].Begin })
+		for _, block := range replaced {
+			replacement := strings.Split(renderManagedBlock(block.ID, strings.Join(block.Body, "\n")), "\n")
+			lines = append(lines[:block.Begin], append(replacement, lines[block.End+1:]...)...)
+		}
+
+		updated := strings.Join(lines, "\n")
+		if updated == current {
+			continue
+		}
+		changed = true
+		if opts.dryRun {
+			fmt.Fprint(out, unifiedDiff("a/"+doc, "b/"+doc, current, updated))
+			continue
+		}
+		if err := writeFile(filepath.Join(opts.repoPath, doc), updated, 0o644); err != nil {
+			return 1, err
+		}
+	}
+
+	// Repos scaffolded before the base was kept get one on their first sync.
+	if !opts.dryRun && profile != profileCore && managed > 0 && (changed || !source.isFile(managedBasePath)) {
+		if err := writeManagedBase(opts.repoPath); err != nil {
+			return 1, err
+		}
+	}
+	if conflicts > 0 {
+		fmt.Fprintf(out, "%d managed blocks need a manual merge; copy in the Seed text to accept it, or delete the markers to keep your version\n", conflicts)
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// historyBase finds the generated text a block's marker hash was computed from
+// in the doc's git history, for repos without a matching base in managedBasePath.
+func historyBase(repoPath, doc string, block managedBlock) (string, bool) {
+	commits, err := gitOutput(repoPath, "log", "--format=%H", "--", doc)
+	if err != nil {
+		return "", false
+	}
+	for _, commit := range strings.Fields(commits) {
+		content, err := gitOutput(repoPath, "show", commit+":./"+doc)
+		if err != nil {
+			continue
+		}
+		for _, old := range parseManagedBlocks(content) {
+			if old.ID == block.ID && managedHash(old.Body) == block.Hash {
+				return strings.Join(old.Body, "\n"), true
+			}
+		}
+	}
+	return "", false
+}
+
+// syncBlockState decides what sync may do with one block.
+func syncBlockState(block, next managedBlock, found bool) string {
+	switch {
+	case !found:
+		return blockUnmanaged
+	case managedHash(block.Body) == next.Hash:
+		// Text already matches; a stale hash is refreshed so later edits are detected.
+		if block.Hash != next.Hash {
+			return blockUpdated
+		}
+		return blockCurrent
+	case managedHash(block.Body) == block.Hash:
+		return blockUpdated
+	case block.Hash == next.Hash:
+		// Only the user changed the block; there is nothing new to merge in.
+		return blockEdited
+	default:
+		return blockConflict
+	}
+}
+
+// renderConflict prints diff3-style markers; the base is omitted when no matching copy was recorded.
+func renderConflict(doc string, local []string, base string, hasBase bool, next []string) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "<<<<<<< %s (local)\n", doc)
+	for _, line := range local {
+		builder.WriteString(line + "\n")
+	}
+	if hasBase {
+		builder.WriteString("||||||| generated\n")
+		builder.WriteString(base + "\n")
+	} else {
+		fmt.Fprintf(&builder, "||||||| generated (no base copy in %s or git history)\n", managedBasePath)
+	}
+	builder.WriteString("=======\n")
+	for _, line := range next {
+		builder.WriteString(line + "\n")
+	}
+	fmt.Fprintf(&builder, ">>>>>>> seed %s\n", cliVersion)
+	return builder.String()
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSyncManagedBlocks(t *testing.T) {
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "llm")
+	mustScaffoldProfile(t, target, profileLLM, manifest)
+
+	var out bytes.Buffer
+	if code, err := runSync(syncOptions{repoPath: target}, &out); err != nil || code != 0 {
+		t.Fatalf("fresh scaffold should sync cleanly, got %d %v\n%s", code, err, out.String())
+	}
+	if !strings.Contains(out.String(), "AGENTS.md: agents-poc-guardrails current") {
+		t.Fatalf("unexpected sync output:\n%s", out.String())
+	}
+
+	// Simulate blocks generated by an older Seed: one untouched, one edited locally since.
+	agentsPath := filepath.Join(target, "AGENTS.md")
+	contextPath := filepath.Join(target, "CONTEXT.md")
+	mustRewriteFile(t, agentsPath, olderManagedBlock("agents-upgrade-triggers", "- Older trigger wording."))
+	mustRewriteFile(t, contextPath, olderManagedBlock("context-poc-philosophy", "- Older philosophy."))
+	mustRewriteFile(t, contextPath, func(content string) string {
+		return strings.Replace(content, "- Older philosophy.", "- Our own philosophy.", 1)
+	})
+	mustRewriteFile(t, agentsPath, func(content string) string {
+		return strings.Replace(content, "- Keep changes small and focused on the user request.", "- Keep changes tiny.", 1)
+	})
+	basePath := filepath.Join(target, filepath.FromSlash(managedBasePath))
+	mustRewriteFile(t, basePath, func(content string) string {
+		return strings.Replace(content, `"context-poc-philosophy": "`+strings.ReplaceAll(contextPOCPhilosophy, "\n", `\n`)+`"`, `"context-poc-philosophy": "- Older philosophy."`, 1)
+	})
+
+	out.Reset()
+	code, err := runSync(syncOptions{repoPath: target}, &out)
+	if err != nil || code != 1 {
+		t.Fatalf("expected a conflict, got %d %v\n%s", code, err, out.String())
+	}
+	for _, want := range []string{
+		"AGENTS.md: agents-upgrade-triggers updated",
+		"AGENTS.md: agents-working-rules edited",
+		"CONTEXT.md: context-poc-philosophy conflict",
+		"<<<<<<< CONTEXT.md (local)\n- Our own philosophy.\n||||||| generated\n- Older philosophy.\n=======\n" + contextPOCPhilosophy + "\n>>>>>>> seed " + cliVersion,
+		"1 managed blocks need a manual merge",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("sync output missing %q:\n%s", want, out.String())
+		}
+	}
+	agents := mustReadFile(t, agentsPath)
+	if !strings.Contains(agents, agentsUpgradeTriggers) || !strings.Contains(agents, "- Keep changes tiny.") {
+		t.Fatalf("sync should update untouched blocks and keep edited ones:\n%s", agents)
+	}
+	if !strings.Contains(mustReadFile(t, contextPath), "- Our own philosophy.") {
+		t.Fatal("conflicting block must not be overwritten")
+	}
+
+	// Deleting the markers hands the block to the user and clears the conflict.
+	mustRewriteFile(t, contextPath, func(content string) string {
+		block := parseManagedBlocks(content)
+		lines := strings.Split(content, "\n")
+		for i := len(block) - 1; i >= 0; i-- {
+			if block[i].ID == "context-poc-philosophy" {
+				lines = append(lines[:block[i].End], lines[block[i].End+1:]...)
+				lines = append(lines[:block[i].Begin], lines[block[i].Begin+1:]...)
+			}
+		}
+		return strings.Join(lines, "\n")
+	})
+	out.Reset()
+	if code, err := runSync(syncOptions{repoPath: target}, &out); err != nil || code != 0 {
+		t.Fatalf("expected clean sync after taking ownership, got %d %v\n%s", code, err, out.String())
+	}
+	if !strings.Contains(out.String(), "CONTEXT.md: context-poc-philosophy unmanaged") {
+		t.Fatalf("unexpected sync output:\n%s", out.String())
+	}
+}
+
+func TestSyncAdoptsUnmarkedSections(t *testing.T) {
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "llm")
+	mustScaffoldProfile(t, target, profileLLM, manifest)
+
+	// Rebuild the docs a release without markers wrote: same sections, no markers, no base.
+	basePath := filepath.Join(target, filepath.FromSlash(managedBasePath))
+	if err := os.Remove(basePath); err != nil {
+		t.Fatalf("remove managed base: %v", err)
+	}
+	for _, doc := range seedDocs {
+		mustRewriteFile(t, filepath.Join(target, doc), func(content string) string {
+			kept := make([]string, 0)
+			for _, line := range strings.Split(content, "\n") {
+				if !managedBeginPattern.MatchString(line) && !managedEndPattern.MatchString(line) {
+					kept = append(kept, line)
+				}
+			}
+			return strings.Join(kept, "\n")
+		})
+	}
+	agentsPath := filepath.Join(target, "AGENTS.md")
+	contextPath := filepath.Join(target, "CONTEXT.md")
+	// Core repos were generated without the skill line, so this is older Seed text.
+	skillRule := "- Use skills/seed-validate/SKILL.md for nuanced drift checks when making large structure/doc changes.\n"
+	mustRewriteFile(t, agentsPath, func(content string) string {
+		return strings.Replace(content, skillRule, "", 1)
+	})
+	mustRewriteFile(t, contextPath, func(content string) string {
+		return strings.Replace(content, contextPOCPhilosophy, "- Our own philosophy.", 1)
+	})
+
+	var out bytes.Buffer
+	if code, err := runSync(syncOptions{repoPath: target}, &out); err != nil || code != 0 {
+		t.Fatalf("expected adoption to succeed, got %d %v\n%s", code, err, out.String())
+	}
+	for _, want := range []string{
+		"AGENTS.md: agents-working-rules adopted",
+		"AGENTS.md: agents-poc-guardrails adopted",
+		"README.md: readme-seed-files adopted",
+		"CONTEXT.md: context-poc-philosophy unmanaged",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("sync output missing %q:\n%s", want, out.String())
+		}
+	}
+	agents := mustReadFile(t, agentsPath)
+	if !strings.Contains(agents, skillRule) || len(parseManagedBlocks(agents)) != 3 {
+		t.Fatalf("adopted sections should be wrapped and updated:\n%s", agents)
+	}
+	context := mustReadFile(t, contextPath)
+	if !strings.Contains(context, "- Our own philosophy.") || strings.Contains(context, "seed:managed id=context-poc-philosophy") {
+		t.Fatalf("edited sections must not be adopted:\n%s", context)
+	}
+	mustBeFile(t, basePath)
+
+	out.Reset()
+	if code, err := runSync(syncOptions{repoPath: target}, &out); err != nil || code != 0 {
+		t.Fatalf("expected a clean second sync, got %d %v\n%s", code, err, out.String())
+	}
+	if !strings.Contains(out.String(), "AGENTS.md: agents-working-rules current") || strings.Contains(out.String(), blockAdopted) {
+		t.Fatalf("unexpected second sync output:\n%s", out.String())
+	}
+}
+
+func TestSyncCoreFindsBaseInGitHistory(t *testing.T) {
+	requireGit(t)
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "core")
+	mustScaffoldGitRepo(t, target, profileCore, manifest)
+
+	// An older Seed generated the block and it was committed; the user edited it since.
+	contextPath := filepath.Join(target, "CONTEXT.md")
+	mustRewriteFile(t, contextPath, olderManagedBlock("context-poc-philosophy", "- Older philosophy."))
+	mustCommitAll(t, target, "older seed docs")
+	mustRewriteFile(t, contextPath, func(content string) string {
+		return strings.Replace(content, "- Older philosophy.", "- Our own philosophy.", 1)
+	})
+
+	var out bytes.Buffer
+	code, err := runSync(syncOptions{repoPath: target}, &out)
+	if err != nil || code != 1 {
+		t.Fatalf("expected a conflict, got %d %v\n%s", code, err, out.String())
+	}
+	want := "<<<<<<< CONTEXT.md (local)\n- Our own philosophy.\n||||||| generated\n- Older philosophy.\n=======\n"
+	if !strings.Contains(out.String(), want) {
+		t.Fatalf("conflict should show the base from git history:\n%s", out.String())
+	}
+	mustBeMissing(t, filepath.Join(target, ".seed"))
+}
+
+// olderManagedBlock replaces block id with body, as an older Seed would have rendered it.
+func olderManagedBlock(id, body string) func(string) string {
+	return func(content string) string {
+		blocks := parseManagedBlocks(content)
+		lines := strings.Split(content, "\n")
+		for _, block := range blocks {
+			if block.ID == id {
+				replacement := strings.Split(renderManagedBlock(id, body), "\n")
+				lines = append(lines[:block.Begin], append(replacement, lines[block.End+1:]...)...)
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+}
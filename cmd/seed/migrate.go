@@ -0,0 +1,334 @@
+package main
+
+// Format migrations move a repo's .seed/manifest.json forward one seed_format_version at a time.
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const commandMigrate = "migrate"
+
+const reasonFormatOutdated = "format_outdated"
+
+type migrateOptions struct {
+	repoPath string
+	dryRun   bool
+}
+
+// formatMigration rewrites a decoded manifest snapshot from one format version to the next.
+// current is the CLI's own snapshot for the repo's profile, for steps that add new settings.
+type formatMigration struct {
+	from    string
+	to      string
+	summary string
+	apply   func(snapshot, current map[string]any)
+}
+
+// formatMigrations must form a chain that ends at the canonical seed_format_version.
+var formatMigrations = []formatMigration{
+	{
+		from:    "2.0.0",
+		to:      "2.1.0",
+		summary: "add the settings introduced since 2.0.0 with the current defaults; require the pre-push hook and .seed/rules.tsv in guarded repos",
+		apply: func(snapshot, current map[string]any) {
+			// 2.0.0 scaffolds wrote only the file, heading and signal lists, so every
+			// other setting is backfilled.
+			for _, key := range sortedKeys(current) {
+				backfillSetting(snapshot, current, key)
+			}
+			if snapshot["active_profile"] == profileGuarded {
+				for _, file := range []string{".seed/hooks/pre-push", rulesFilePath} {
+					insertRequiredFile(snapshot, current, file)
+				}
+			}
+		},
+	},
+}
+
+// insertRequiredFile adds a file to required_files after the file it follows in the
+// CLI's snapshot, or at the end when that file is not listed.
+func insertRequiredFile(snapshot, current map[string]any, file string) {
+	required, _ := snapshot["required_files"].([]any)
+	if indexValue(required, file) >= 0 {
+		return
+	}
+	at := len(required)
+	scaffold, _ := current["required_files"].([]any)
+	if i := indexValue(scaffold, file); i > 0 {
+		if previous := indexValue(required, scaffold[i-1]); previous >= 0 {
+			at = previous + 1
+		}
+	}
+	snapshot["required_files"] = append(required[:at:at], append([]any{file}, required[at:]...)...)
+}
+
+func indexValue(values []any, want any) int {
+	for i, value := range values {
+		if value == want {
+			return i
+		}
+	}
+	return -1
+}
+
+// backfillSetting copies a setting the repo manifest lacks from the CLI's snapshot.
+func backfillSetting(snapshot, current map[string]any, key string) {
+	if _, ok := snapshot[key]; !ok && current[key] != nil {
+		snapshot[key] = current[key]
+	}
+}
+
+// compareFormatVersions compares dotted numeric versions; missing parts count as zero.
+func compareFormatVersions(a, b string) int {
+	left, right := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(left), len(right)); i++ {
+		var x, y int
+		if i < len(left) {
+			x, _ = strconv.Atoi(left[i])
+		}
+		if i < len(right) {
+			y, _ = strconv.Atoi(right[i])
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+// checkFormatVersion mirrors the format check in seed-test.sh.
+func checkFormatVersion(rules manifestSnapshot, report *layoutReport) {
+	manifest, err := loadCanonicalManifest()
+	if err != nil || rules.SeedFormatVersion == "" {
+		return
+	}
+	if compareFormatVersions(rules.SeedFormatVersion, manifest.SeedFormatVersion) < 0 {
+		report.addWarning(reasonFormatOutdated, ".seed/manifest.json",
+			"Seed format %s in .seed/manifest.json is older than %s; run seed migrate", rules.SeedFormatVersion, manifest.SeedFormatVersion)
+	}
+}
+
+func newerFormatError(version, supported string) error {
+	return fmt.Errorf("Seed format %s in .seed/manifest.json is newer than this CLI supports (%s); upgrade seed to work with this repo", version, supported)
+}
+
+func parseMigrateArgs(opts options, args []string) (options, error) {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		opts.migrate.repoPath = args[0]
+		args = args[1:]
+	}
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run":
+			opts.migrate.dryRun = true
+		case "-h", "--help":
+			opts.showHelp = true
+		default:
+			return opts, fmt.Errorf("unknown argument: %s", arg)
+		}
+	}
+	return opts, nil
+}
+
+func printMigrateUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: seed migrate [repo-path] [--dry-run]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Upgrade .seed/manifest.json to this CLI's seed_format_version one release at a time,")
+	fmt.Fprintln(w, "then regenerate .seed/rules.tsv and unmodified guarded scripts.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	fmt.Fprintln(w, "  --dry-run  Show the manifest diff without writing")
+}
+
+func runMigrate(opts migrateOptions, out io.Writer) error {
+	manifestPath := filepath.Join(opts.repoPath, ".seed", "manifest.json")
+	original, err := os.ReadFile(manifestPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s has no .seed/manifest.json; core repos have no format to migrate", opts.repoPath)
+	}
+	if err != nil {
+		return fmt.Errorf("read .seed/manifest.json: %w", err)
+	}
+	canonical, err := loadCanonicalManifest()
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(original))
+	decoder.UseNumber()
+	var snapshot map[string]any
+	if err := decoder.Decode(&snapshot); err != nil {
+		return fmt.Errorf("parse .seed/manifest.json: %w", err)
+	}
+	version, _ := snapshot["seed_format_version"].(string)
+	if version == "" {
+		return errors.New(".seed/manifest.json has no seed_format_version; rescaffold or add it by hand")
+	}
+	switch compareFormatVersions(version, canonical.SeedFormatVersion) {
+	case 1:
+		return newerFormatError(version, canonical.SeedFormatVersion)
+	case 0:
+		fmt.Fprintf(out, "Already at Seed format %s\n", version)
+		return nil
+	}
+
+	profile, _ := snapshot["active_profile"].(string)
+	current, err := currentSnapshotMap(canonical, profile)
+	if err != nil {
+		return err
+	}
+	for version != canonical.SeedFormatVersion {
+		step, ok := findMigration(version)
+		if !ok {
+			return fmt.Errorf("no migration from Seed format %s to %s; rescaffold or edit .seed/manifest.json by hand", version, canonical.SeedFormatVersion)
+		}
+		step.apply(snapshot, current)
+		snapshot["seed_format_version"] = step.to
+		fmt.Fprintf(out, "format %s -> %s: %s\n", step.from, step.to, step.summary)
+		version = step.to
+	}
+
+	migrated, err := encodeMigratedManifest(snapshot)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(out, unifiedDiff("a/.seed/manifest.json", "b/.seed/manifest.json", string(original), migrated))
+	if err := decodeManifest([]byte(migrated), seededSchemaPath, &manifestSnapshot{}); err != nil {
+		return fmt.Errorf("migrated .seed/manifest.json is invalid; fix it by hand or with seed manifest lint: %w", err)
+	}
+	if opts.dryRun {
+		return nil
+	}
+	if err := writeFile(manifestPath, migrated, 0o644); err != nil {
+		return err
+	}
+	if err := recordLockEntry(opts.repoPath, ".seed/manifest.json", migrated); err != nil {
+		return err
+	}
+
+	if profile == profileGuarded {
+		if err := writeRulesFile(opts.repoPath, []byte(migrated)); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s: regenerated from .seed/manifest.json\n", rulesFilePath)
+		for _, script := range guardedScripts {
+			state, _ := inspectScript(opts.repoPath, script)
+			if state.State == scriptModified {
+				fmt.Fprintf(out, "%s: kept local changes; run seed refresh-scripts --force to replace it\n", script.path)
+				continue
+			}
+			if err := writeGuardedScript(opts.repoPath, script, version); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "%s: updated to seed %s (format %s)\n", script.path, cliVersion, version)
+		}
+	}
+	fmt.Fprintf(out, "Migrated to Seed format %s\n", version)
+	return nil
+}
+
+func findMigration(from string) (formatMigration, bool) {
+	for _, step := range formatMigrations {
+		if step.from == from {
+			return step, true
+		}
+	}
+	return formatMigration{}, false
+}
+
+func currentSnapshotMap(canonical canonicalManifest, profile string) (map[string]any, error) {
+	if !validProfiles[profile] {
+		profile = canonical.DefaultProfile
+	}
+	snapshot, err := manifestForProfile(canonical, profile)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("marshal profile manifest: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var current map[string]any
+	if err := decoder.Decode(&current); err != nil {
+		return nil, fmt.Errorf("decode profile manifest: %w", err)
+	}
+	return current, nil
+}
+
+// encodeMigratedManifest writes the snapshot map itself, so settings the repo leaves
+// out stay out instead of becoming zero values. Known keys come first in scaffold
+// order and keep their field order when that loses nothing; unknown keys follow sorted.
+func encodeMigratedManifest(snapshot map[string]any) (string, error) {
+	fields := map[string]reflect.Type{}
+	order := make([]string, 0, len(snapshot))
+	for _, field := range reflect.VisibleFields(reflect.TypeOf(manifestSnapshot{})) {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		fields[name] = field.Type
+		if _, ok := snapshot[name]; ok {
+			order = append(order, name)
+		}
+	}
+	for _, key := range sortedKeys(snapshot) {
+		if _, ok := fields[key]; !ok {
+			order = append(order, key)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("{")
+	for i, key := range order {
+		value, err := encodeMigratedValue(snapshot[key], fields[key])
+		if err != nil {
+			return "", fmt.Errorf("marshal .seed/manifest.json %s: %w", key, err)
+		}
+		name, _ := json.Marshal(key)
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, "\n  %s: %s", name, value)
+	}
+	b.WriteString("\n}\n")
+	return b.String(), nil
+}
+
+// encodeMigratedValue indents one top-level value, going through its typed field
+// only when decoding and re-encoding gives back the same JSON.
+func encodeMigratedValue(value any, typ reflect.Type) ([]byte, error) {
+	plain, err := json.MarshalIndent(value, "  ", "  ")
+	if err != nil || typ == nil {
+		return plain, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(plain))
+	decoder.DisallowUnknownFields()
+	typed := reflect.New(typ)
+	if decoder.Decode(typed.Interface()) != nil {
+		return plain, nil
+	}
+	ordered, err := json.MarshalIndent(typed.Interface(), "  ", "  ")
+	if err != nil || !sameJSON(plain, ordered) {
+		return plain, nil
+	}
+	return ordered, nil
+}
+
+func sameJSON(a, b []byte) bool {
+	var left, right any
+	if json.Unmarshal(a, &left) != nil || json.Unmarshal(b, &right) != nil {
+		return false
+	}
+	return reflect.DeepEqual(left, right)
+}
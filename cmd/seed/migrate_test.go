@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMigrateFormat(t *testing.T) {
+	requireGit(t)
+
+	for _, tc := range []struct {
+		a, b string
+		want int
+	}{
+		{"2.0.0", "2.1.0", -1},
+		{"2.10.0", "2.9.1", 1},
+		{"2.1", "2.1.0", 0},
+		{"3.0.0", "2.99.99", 1},
+	} {
+		if got := compareFormatVersions(tc.a, tc.b); got != tc.want {
+			t.Fatalf("compareFormatVersions(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "guarded")
+	mustScaffoldGitRepo(t, target, profileGuarded, manifest)
+	manifestPath := filepath.Join(target, ".seed", "manifest.json")
+	scaffolded := mustReadFile(t, manifestPath)
+
+	// Rewrite the manifest the way a 2.0.0 scaffold wrote it.
+	var legacy map[string]any
+	if err := json.Unmarshal([]byte(scaffolded), &legacy); err != nil {
+		t.Fatalf("parse manifest: %v", err)
+	}
+	legacy["seed_format_version"] = "2.0.0"
+	for _, key := range []string{"misplaced_content_scope", "misplaced_content_phrases", "integrity", "file_rules"} {
+		delete(legacy, key)
+	}
+	required := make([]any, 0)
+	for _, file := range legacy["required_files"].([]any) {
+		if file != rulesFilePath {
+			required = append(required, file)
+		}
+	}
+	legacy["required_files"] = required
+	encoded, err := json.MarshalIndent(legacy, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal legacy manifest: %v", err)
+	}
+	if err := os.WriteFile(manifestPath, append(encoded, '\n'), 0o644); err != nil {
+		t.Fatalf("write legacy manifest: %v", err)
+	}
+	if err := writeRulesFile(target, append(encoded, '\n')); err != nil {
+		t.Fatalf("regenerate rules.tsv: %v", err)
+	}
+
+	shellOutput, code := runCommandWithExit(t, exec.Command("sh", filepath.Join(target, ".seed", "seed-test.sh")))
+	if code != 2 || parseSeedStatus(shellOutput)["SEED_TRIGGER_REASONS"] != reasonFormatOutdated ||
+		!strings.Contains(shellOutput, "Seed format 2.0.0 in .seed/manifest.json is older than "+manifest.SeedFormatVersion+"; run seed migrate") {
+		t.Fatalf("seed-test.sh should warn about the old format, got exit %d:\n%s", code, shellOutput)
+	}
+	source := worktreeSource{root: target}
+	rules, _, err := loadRepoRules(source, profileGuarded)
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+	if reasons := validateContract(source, rules, contractOptions{today: time.Now()}).triggerReasons(); len(reasons) != 1 || reasons[0] != reasonFormatOutdated {
+		t.Fatalf("Go rules should warn about the old format: %v", reasons)
+	}
+
+	var out bytes.Buffer
+	if err := runMigrate(migrateOptions{repoPath: target}, &out); err != nil {
+		t.Fatalf("migrate: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "format 2.0.0 -> 2.1.0") || !strings.Contains(out.String(), "Migrated to Seed format "+manifest.SeedFormatVersion) {
+		t.Fatalf("unexpected migrate output:\n%s", out.String())
+	}
+	if migrated := mustReadFile(t, manifestPath); migrated != scaffolded {
+		t.Fatalf("migrated manifest should match a fresh scaffold:\n%s", unifiedDiff("scaffolded", "migrated", scaffolded, migrated))
+	}
+	if shellOutput, code := runCommandWithExit(t, exec.Command("sh", filepath.Join(target, ".seed", "seed-test.sh"))); code != 0 {
+		t.Fatalf("migrated repo should validate, got exit %d:\n%s", code, shellOutput)
+	}
+	out.Reset()
+	if err := runMigrate(migrateOptions{repoPath: target}, &out); err != nil || !strings.Contains(out.String(), "Already at Seed format") {
+		t.Fatalf("second migrate should be a no-op: %v\n%s", err, out.String())
+	}
+
+	// A repo written by a newer Seed is refused by both validators and by migrate.
+	mustRewriteFile(t, manifestPath, func(content string) string {
+		return strings.Replace(content, `"seed_format_version": "`+manifest.SeedFormatVersion+`"`, `"seed_format_version": "99.0.0"`, 1)
+	})
+	if err := writeRulesFile(target, []byte(mustReadFile(t, manifestPath))); err != nil {
+		t.Fatalf("regenerate rules.tsv: %v", err)
+	}
+	shellOutput, code = runCommandWithExit(t, exec.Command("sh", filepath.Join(target, ".seed", "seed-test.sh")))
+	if code != 1 || parseSeedStatus(shellOutput)["SEED_TRIGGER_REASONS"] != "format_unsupported" {
+		t.Fatalf("seed-test.sh should refuse a newer format, got exit %d:\n%s", code, shellOutput)
+	}
+	if _, _, err := loadRepoRules(source, profileGuarded); err == nil || !strings.Contains(err.Error(), "newer than this CLI supports") {
+		t.Fatalf("Go rules should refuse a newer format, got %v", err)
+	}
+	if err := runMigrate(migrateOptions{repoPath: target}, &out); err == nil || !strings.Contains(err.Error(), "upgrade seed") {
+		t.Fatalf("migrate should refuse a newer format, got %v", err)
+	}
+}
+
+// Manifests written by the 2.0.0 scaffold lack every setting added since, and must
+// migrate to exactly what a fresh scaffold writes.
+func TestMigrateBaselineManifest(t *testing.T) {
+	requireGit(t)
+	manifest := mustLoadManifest(t)
+
+	for _, profile := range []string{profileLLM, profileGuarded} {
+		target := filepath.Join(t.TempDir(), profile)
+		mustScaffoldGitRepo(t, target, profile, manifest)
+		manifestPath := filepath.Join(target, ".seed", "manifest.json")
+		scaffolded := mustReadFile(t, manifestPath)
+		baseline := mustReadFile(t, filepath.Join("testdata", "manifest-2.0.0-"+profile+".json"))
+		if err := os.WriteFile(manifestPath, []byte(baseline), 0o644); err != nil {
+			t.Fatalf("write baseline manifest: %v", err)
+		}
+
+		var out bytes.Buffer
+		if err := runMigrate(migrateOptions{repoPath: target}, &out); err != nil {
+			t.Fatalf("%s: migrate baseline manifest: %v\n%s", profile, err, out.String())
+		}
+		if migrated := mustReadFile(t, manifestPath); migrated != scaffolded {
+			t.Fatalf("%s: migrated baseline manifest should match a fresh scaffold:\n%s", profile, unifiedDiff("scaffolded", "migrated", scaffolded, migrated))
+		}
+	}
+}
+
+func TestValidateLayoutFlagsOutdatedFormatWithOldScripts(t *testing.T) {
+	requireGit(t)
+
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "guarded")
+	mustScaffoldGitRepo(t, target, profileGuarded, manifest)
+	mustResolvePlaceholders(t, target)
+	// A 2.0.0 repo: its seed-test.sh predates format checks and passes the old manifest.
+	for _, path := range []string{"seed-test.sh", "install-hooks.sh", "hooks/pre-commit"} {
+		legacy := mustReadFile(t, filepath.Join("testdata", "guarded-2.0.0", filepath.FromSlash(path)))
+		mustRewriteFile(t, filepath.Join(target, ".seed", filepath.FromSlash(path)), func(string) string { return legacy })
+	}
+	legacyManifest := mustReadFile(t, filepath.Join("testdata", "manifest-2.0.0-guarded.json"))
+	mustRewriteFile(t, filepath.Join(target, ".seed", "manifest.json"), func(string) string { return legacyManifest })
+	if output, code := runCommandWithExit(t, exec.Command("sh", filepath.Join(target, ".seed", "seed-test.sh"))); code != 0 {
+		t.Fatalf("the 2.0.0 script should pass its own format, got exit %d:\n%s", code, output)
+	}
+
+	var out, errOut bytes.Buffer
+	code := runValidateLayout(validateLayoutOptions{repoPath: target}, &out, &errOut)
+	fields := parseSeedStatus(out.String())
+	if code != 0 || fields["SEED_STATUS"] != statusSkillRecommended || fields["SEED_WARNINGS"] != "1" || fields["SEED_TRIGGER_REASONS"] != reasonFormatOutdated {
+		t.Fatalf("validate-layout should add the format warning, got exit %d:\n%s", code, out.String())
+	}
+	if strings.Count(out.String(), "SEED_STATUS=") != 1 || !strings.Contains(out.String(), "SEED_NEXT_ACTION=run_seed_validate_skill") ||
+		!strings.Contains(out.String(), "seed-layout-validation: warnings present, skill recommended") {
+		t.Fatalf("the script's status block should be replaced, not repeated:\n%s", out.String())
+	}
+	if !strings.Contains(errOut.String(), "Seed format 2.0.0 in .seed/manifest.json is older than "+manifest.SeedFormatVersion+"; run seed migrate") {
+		t.Fatalf("validate-layout should print the format warning:\n%s", errOut.String())
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+// Rule overrides let a seeded repo adjust its manifest rules locally; every override is reported so none go unnoticed.
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+const (
+	overridesPath          = ".seed/overrides.json"
+	reasonInvalidOverrides = "invalid_overrides"
+	// reasonRuleOverride tags the info findings that list each override.
+	reasonRuleOverride = "rule_override"
+)
+
+// severityOff drops findings for a rule; it only appears in rule_severities and overrides.
+const severityOff = "off"
+
+// adjustableReasons are the findings rule_severities and overrides may change. Integrity
+// findings (missing manifest or rules, rules_mismatch, validator edits, invalid overrides)
+// always keep their severity and cannot be exempted.
+var adjustableReasons = []string{
+	reasonFormatOutdated,
+	reasonMissingFile,
+	reasonMissingFileMatch,
+	reasonForbiddenFile,
+	reasonMissingHeading,
+	reasonHeadingAlias,
+	reasonHeadingNearMiss,
+	reasonEmptySection,
+	reasonEmptyListField,
+	reasonUnresolvedPlaceholder,
+	reasonInvalidDecision,
+	reasonIncompleteDecision,
+	reasonDecisionOrder,
+	reasonDuplicateDecision,
+	reasonTodoSectionMissing,
+	reasonTodoSectionOrder,
+	reasonTodoCheckbox,
+	reasonTodoDoneOverflow,
+	reasonTodoBlockersConflict,
+	reasonMisplacedContent,
+	reasonMisplacedPhrase,
+	reasonStaleStatus,
+	reasonStaleTodo,
+	reasonUndocumentedDependency,
+}
+
+type ruleOverrides struct {
+	// Severities replace the manifest severity for a finding reason.
+	Severities map[string]string `json:"severities,omitempty"`
+	// RequiredHeadings are "file::heading" specs checked on top of the manifest's.
+	RequiredHeadings []string `json:"required_headings,omitempty"`
+	// ExemptFiles are globs; findings about matching files are dropped.
+	ExemptFiles []string `json:"exempt_files,omitempty"`
+}
+
+// readOverridesFile returns the content of .seed/overrides.json, or nil when
+// the repo has none; an empty file is returned as a non-nil empty slice.
+func readOverridesFile(source repoSource) ([]byte, error) {
+	content, err := source.readFile(overridesPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		content = []byte{}
+	}
+	return content, nil
+}
+
+// rulesMatchOverrides reports whether rules.tsv was generated from the current
+// .seed/overrides.json, or from none when overridesBytes is nil.
+func rulesMatchOverrides(rulesBytes, overridesBytes []byte) bool {
+	if overridesBytes == nil {
+		return recordedOverridesHash(rulesBytes) == ""
+	}
+	return recordedOverridesHash(rulesBytes) == contentHash(string(overridesBytes))
+}
+
+// overridesProblem flattens an overrides error onto one line, as rules.tsv records it.
+func overridesProblem(err error) string {
+	return strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(err.Error())
+}
+
+// loadOverrides reads .seed/overrides.json; a repo without one has no overrides.
+// Guarded repos only apply the overrides flattened into rules.tsv, so overrides
+// edited since the last seed refresh-scripts are ignored, as seed-test.sh does.
+func loadOverrides(source repoSource) (ruleOverrides, error) {
+	content, err := readOverridesFile(source)
+	if err != nil || content == nil {
+		return ruleOverrides{}, err
+	}
+	if rulesBytes, err := source.readFile(rulesFilePath); err == nil && !rulesMatchOverrides(rulesBytes, content) {
+		return ruleOverrides{}, nil
+	}
+	var overrides ruleOverrides
+	if err := decodeManifest(content, overridesSchemaPath, &overrides); err != nil {
+		return ruleOverrides{}, err
+	}
+	return overrides, nil
+}
+
+// checkOverrides loads the repo overrides and notes each one in the report; an
+// invalid file is an error and leaves the manifest rules untouched.
+func checkOverrides(source repoSource, report *layoutReport) ruleOverrides {
+	overrides, err := loadOverrides(source)
+	if err != nil {
+		report.addError(reasonInvalidOverrides, overridesPath, "Invalid %s: %s", overridesPath, overridesProblem(err))
+		return ruleOverrides{}
+	}
+	for _, reason := range sortedKeys(overrides.Severities) {
+		report.addInfo(reasonRuleOverride, overridesPath,
+			"Override: %s findings are %s (%s)", reason, overrides.Severities[reason], overridesPath)
+	}
+	for _, spec := range overrides.RequiredHeadings {
+		file, heading := splitHeadingSpec(spec)
+		report.addInfo(reasonRuleOverride, overridesPath,
+			"Override: heading \"%s\" is required in %s (%s)", heading, file, overridesPath)
+	}
+	for _, glob := range overrides.ExemptFiles {
+		report.addInfo(reasonRuleOverride, overridesPath,
+			"Override: findings for %s are exempt (%s)", glob, overridesPath)
+	}
+	return overrides
+}
+
+// apply adds the override headings to a copy of the manifest rules.
+func (o ruleOverrides) apply(rules manifestSnapshot) manifestSnapshot {
+	headings := append([]string{}, rules.RequiredHeadings...)
+	for _, spec := range o.RequiredHeadings {
+		if !containsString(headings, spec) {
+			headings = append(headings, spec)
+		}
+	}
+	rules.RequiredHeadings = headings
+	return rules
+}
+
+// adjustFindings applies file exemptions, then the override or manifest severity,
+// to findings with adjustable reasons. Info notes keep their severity unless the
+// rule is off, so raising a rule never turns a note into a failure.
+func adjustFindings(findings []layoutFinding, severities map[string]string, overrides ruleOverrides) []layoutFinding {
+	adjusted := make([]layoutFinding, 0, len(findings))
+	exempt := compileGlobs(overrides.ExemptFiles)
+	for _, finding := range findings {
+		if !containsString(adjustableReasons, finding.Reason) {
+			adjusted = append(adjusted, finding)
+			continue
+		}
+		if finding.File != "" && exempt.match(finding.File) {
+			continue
+		}
+		severity, ok := overrides.Severities[finding.Reason]
+		if !ok {
+			severity = severities[finding.Reason]
+		}
+		switch {
+		case severity == severityOff:
+			continue
+		case severity != "" && finding.Severity != severityInfo:
+			finding.Severity = severity
+		}
+		adjusted = append(adjusted, finding)
+	}
+	return adjusted
+}
+
+// checkSeverityReasons reports severities for unknown reasons and for integrity findings.
+func checkSeverityReasons(pointer string, severities map[string]string) []manifestProblem {
+	problems := make([]manifestProblem, 0)
+	for _, reason := range sortedKeys(severities) {
+		if !containsString(adjustableReasons, reason) {
+			problems = append(problems, manifestProblem{pointer + "/" + pointerToken(reason),
+				fmt.Sprintf("%q is not an adjustable finding reason (expected one of %s)", reason, strings.Join(adjustableReasons, ", "))})
+		}
+	}
+	return problems
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRuleOverrides(t *testing.T) {
+	manifest := mustLoadManifest(t)
+	target := t.TempDir()
+	mustScaffoldProfile(t, target, profileLLM, manifest)
+	mustResolvePlaceholders(t, target)
+	overridesFile := filepath.Join(target, ".seed", "overrides.json")
+
+	// Integrity findings and unknown reasons cannot be given a severity.
+	if err := writeFile(overridesFile, `{"severities": {"rules_mismatch": "off", "missing_heding": "info"}, "exempt": []}`+"\n", 0o644); err != nil {
+		t.Fatalf("write overrides: %v", err)
+	}
+	var lintOut bytes.Buffer
+	if code, err := runManifest(manifestOptions{action: manifestLint, path: overridesFile}, &lintOut); err != nil || code != 1 ||
+		!strings.Contains(lintOut.String(), "/exempt: unknown field") || !strings.Contains(lintOut.String(), "(repo overrides, schema seed-contract/overrides.schema.json)") {
+		t.Fatalf("unknown overrides field should fail lint, got %d, %v:\n%s", code, err, lintOut.String())
+	}
+	if err := writeFile(overridesFile, `{"severities": {"rules_mismatch": "off", "missing_heding": "info"}}`+"\n", 0o644); err != nil {
+		t.Fatalf("write overrides: %v", err)
+	}
+	lintOut.Reset()
+	if code, _ := runManifest(manifestOptions{action: manifestLint, path: overridesFile}, &lintOut); code != 1 ||
+		!strings.Contains(lintOut.String(), `/severities/missing_heding: "missing_heding" is not an adjustable finding reason`) ||
+		!strings.Contains(lintOut.String(), `/severities/rules_mismatch: "rules_mismatch" is not an adjustable finding reason`) {
+		t.Fatalf("integrity and unknown reasons should fail lint, got %d:\n%s", code, lintOut.String())
+	}
+	rules, _, err := loadRepoRules(worktreeSource{root: target}, profileLLM)
+	if err != nil {
+		t.Fatalf("load repo rules: %v", err)
+	}
+	report := validateContract(worktreeSource{root: target}, rules, contractOptions{today: time.Now()})
+	if reasons := report.triggerReasons(); strings.Join(reasons, ",") != reasonInvalidOverrides {
+		t.Fatalf("invalid overrides should be the only finding, got %v", report.Findings)
+	}
+
+	// Every override is listed, even when nothing it touches fails.
+	if err := writeFile(overridesFile, `{"severities": {"todo_checkbox": "off"}, "required_headings": ["README.md::Quick Start"], "exempt_files": ["docs/**"]}`+"\n", 0o644); err != nil {
+		t.Fatalf("write overrides: %v", err)
+	}
+	report = validateContract(worktreeSource{root: target}, rules, contractOptions{today: time.Now()})
+	notes := make([]string, 0)
+	for _, finding := range report.Findings {
+		if finding.Reason == reasonRuleOverride {
+			notes = append(notes, finding.Message)
+		}
+	}
+	want := []string{
+		"Override: todo_checkbox findings are off (.seed/overrides.json)",
+		`Override: heading "Quick Start" is required in README.md (.seed/overrides.json)`,
+		"Override: findings for docs/** are exempt (.seed/overrides.json)",
+	}
+	if !reflect.DeepEqual(notes, want) {
+		t.Fatalf("override notes = %q, want %q", notes, want)
+	}
+	if status, _, code := report.outcome(rules.WarningsAsErrors); status != statusOK || code != 0 {
+		t.Fatalf("override notes should not count, got %s (%d): %+v", status, code, report.Findings)
+	}
+}
@@ -6,35 +6,148 @@ import (
 	"strings"
 )
 
-func renderReadme(in scaffoldInput, profile string) string {
-	quickStart := []string{in.RunCommand}
-	if profile == profileGuarded {
-		quickStart = append(quickStart, "git init", "./.seed/install-hooks.sh", "./.seed/seed-test.sh")
+// Seed-owned section bodies; renderers wrap them in seed:managed blocks so seed sync can update them.
+const (
+	contextPOCPhilosophy = `- Keep only artifacts that stay accurate under fast iteration.
+- If a file is unlikely to be maintained when tired, simplify or remove it.
+- Optimize for validated learning speed, not process completeness.
+- Avoid premature contracts/diagrams/roadmaps unless complexity requires them.`
+	contextUpgradeTriggers = `- Move to OpenSpec/Spec Kit when work becomes phased with explicit milestones and handoffs.
+- Move to OpenSpec/Spec Kit when multiple contributors need stronger contracts and review workflows.
+- Move to OpenSpec/Spec Kit when production commitments require heavier planning and governance.`
+	agentsPOCGuardrails = `- Optimize for fast learning and demoable outcomes over completeness.
+- Keep artifacts lightweight; avoid heavy process docs that will go stale.
+- Prefer executable truth in scripts over narrative setup/test instructions.
+- Keep README operational: run path, current status, and immediate caveats.
+- Keep TODO flat and atomic; avoid hierarchy and process overhead.
+- Record only non-obvious decisions; keep entries concise.`
+	agentsUpgradeTriggers = `- Propose moving to OpenSpec/Spec Kit when work becomes phased, multi-team, or contract-heavy.
+- Propose moving to OpenSpec/Spec Kit when production hardening needs explicit planning/governance artifacts.`
+)
+
+// artifactNote describes a generated file in the README "Seed Files" and CONTEXT "Key Files" lists.
+type artifactNote struct {
+	readme  string
+	context string
+}
+
+// artifactNotes cover the files the canonical profiles require; other required files get a generic line.
+var artifactNotes = map[string]artifactNote{
+	"README.md":                     {"project purpose, run path, status, caveats", "project summary, run path, status, limitations"},
+	"DECISIONS.md":                  {"non-obvious decisions and rationale", "non-obvious decisions and rationale"},
+	"TODO.md":                       {"lightweight progress tracking", "active work and short backlog"},
+	"CONTEXT.md":                    {"problem, constraints, success criteria, guardrails", "problem, constraints, success criteria, and guardrails"},
+	"AGENTS.md":                     {"repo-local agent instructions", "concise agent operating guide for this repository"},
+	".seed/manifest.json":           {"local Seed contract snapshot", "local Seed contract snapshot"},
+	".seed/seed-test.sh":            {"structural validator and status emitter", "structural validation entrypoint"},
+	".seed/install-hooks.sh":        {"per-clone hook installer", "one-time hook installer per clone"},
+	".seed/hooks/pre-commit":        {"commit-time validation trigger", "automatic validation trigger"},
+	".seed/hooks/pre-push":          {"push-time validation of every new commit", "validates every pushed commit, including --no-verify ones"},
+	rulesFilePath:                   {"manifest rules flattened for seed-test.sh", "flattened copy of the manifest rules read by seed-test.sh"},
+	"skills/seed-validate/SKILL.md": {"nuanced validation workflow", "nuanced drift analysis workflow"},
+}
+
+// docSection is one "## heading" section of a generated doc.
+// Contract sections are emitted only when the profile rules require their heading.
+type docSection struct {
+	heading  string
+	body     string
+	contract bool
+}
+
+// renderSeedDoc renders one of seedDocs from the rules of the profile it is generated for.
+func renderSeedDoc(file string, in scaffoldInput, rules manifestSnapshot) string {
+	switch file {
+	case "README.md":
+		return renderReadme(in, rules)
+	case "DECISIONS.md":
+		return renderDecisions(in, rules)
+	case "TODO.md":
+		return renderTODO(in, rules)
+	case "CONTEXT.md":
+		return renderContext(in, rules)
+	case "AGENTS.md":
+		return renderAgents(in, rules)
 	}
-	if profile == profileLLM {
-		quickStart = append(quickStart, "# Optional: run skills/seed-validate/SKILL.md for nuanced drift checks")
+	return ""
+}
+
+// renderDoc joins a title block and sections. Required headings the template does not know are
+// appended with placeholder bodies, and required list fields missing from a section are added,
+// so a doc always satisfies the rules it was rendered from.
+func renderDoc(file, head string, sections []docSection, in scaffoldInput, rules manifestSnapshot) string {
+	required := make([]string, 0)
+	for _, spec := range rules.RequiredHeadings {
+		if headingFile, heading := splitHeadingSpec(spec); headingFile == file {
+			required = append(required, heading)
+		}
+	}
+	for _, heading := range required {
+		known := false
+		for _, section := range sections {
+			known = known || section.heading == heading
+		}
+		if !known {
+			sections = append(sections, docSection{
+				heading: heading,
+				body:    "- " + in.filler("section-"+placeholderSlug(heading), "Describe "+heading+" for this project."),
+			})
+		}
 	}
 
-	assetLines := []string{
-		"- `README.md`: project purpose, run path, status, caveats",
-		"- `DECISIONS.md`: non-obvious decisions and rationale",
-		"- `TODO.md`: lightweight progress tracking",
-		"- `CONTEXT.md`: problem, constraints, success criteria, guardrails",
-		"- `AGENTS.md`: repo-local agent instructions",
+	var builder strings.Builder
+	builder.WriteString(head + "\n")
+	for _, section := range sections {
+		if section.contract && !containsString(required, section.heading) {
+			continue
+		}
+		body := section.body
+		for _, spec := range rules.RequiredListFields {
+			fieldFile, rest := splitHeadingSpec(spec)
+			heading, field := splitHeadingSpec(rest)
+			if fieldFile != file || heading != section.heading || listFieldState(strings.Split(body, "\n"), field) != "missing" {
+				continue
+			}
+			line := "- " + field + ":" + in.filler(placeholderSlug(field), "")
+			if body == "" {
+				body = line
+			} else {
+				body += "\n" + line
+			}
+		}
+		builder.WriteString("\n## " + section.heading + "\n")
+		if body != "" {
+			builder.WriteString("\n" + body + "\n")
+		}
 	}
-	if profile != profileCore {
-		assetLines = append(assetLines,
-			"- `.seed/manifest.json`: local Seed contract snapshot",
-			"- `skills/seed-validate/SKILL.md`: nuanced validation workflow",
-		)
+	return builder.String()
+}
+
+// requiredFileList renders one bullet per required file, in manifest order.
+func requiredFileList(rules manifestSnapshot, line func(path string, note artifactNote) string) string {
+	lines := make([]string, 0, len(rules.RequiredFiles))
+	for _, path := range rules.RequiredFiles {
+		note, ok := artifactNotes[path]
+		if !ok {
+			note = artifactNote{"required by the Seed contract", "required by the Seed contract"}
+		}
+		lines = append(lines, line(path, note))
 	}
+	return strings.Join(lines, "\n")
+}
+
+func renderReadme(in scaffoldInput, rules manifestSnapshot) string {
+	profile := rules.ActiveProfile
+	quickStart := []string{in.shellPlaceholder(placeholderRunCommand, in.RunCommand)}
 	if profile == profileGuarded {
-		assetLines = append(assetLines,
-			"- `.seed/seed-test.sh`: structural validator and status emitter",
-			"- `.seed/hooks/pre-commit`: commit-time validation trigger",
-			"- `.seed/install-hooks.sh`: per-clone hook installer",
-		)
+		quickStart = append(quickStart, "git init", "./.seed/install-hooks.sh", "./.seed/seed-test.sh")
 	}
+	if profile == profileLLM {
+		quickStart = append(quickStart, "# Optional: run skills/seed-validate/SKILL.md for nuanced drift checks")
+	}
+	seedFiles := requiredFileList(rules, func(path string, note artifactNote) string {
+		return fmt.Sprintf("- `%s`: %s", path, note.readme)
+	})
 
 	statusDetails := "Ready for implementation."
 	if profile == profileLLM {
@@ -44,125 +157,54 @@ func renderReadme(in scaffoldInput, profile string) string {
 		statusDetails = "Ready for implementation with commit-time structural guardrails."
 	}
 
-	return fmt.Sprintf(`# %s
-
-%s
-
-## Quick Start
-
-%s
-
-## Current Status
-
-%s
-
-## Known Limitations
-
-- %s
-
-## Questions / Issues
-
-%s
-
-## POC Success Criteria
-
-- %s
-
-## Seed Profile
-
-- Active profile: %s
-- %s
-
-## Seed Files
-
-%s
-`,
-		in.ProjectName,
-		in.OneLiner,
-		renderIndentedShell(quickStart),
-		in.StatusLine,
-		in.LimitationLine,
-		in.ContactLine,
-		in.SuccessCriteria,
-		profile,
-		statusDetails,
-		strings.Join(assetLines, "\n"),
-	)
+	return renderDoc("README.md", fmt.Sprintf("# %s\n\n%s", in.ProjectName, in.placeholder(placeholderOneLiner, in.OneLiner)), []docSection{
+		{heading: "Quick Start", body: renderIndentedShell(quickStart), contract: true},
+		{heading: "Current Status", body: in.StatusLine, contract: true},
+		{heading: "Known Limitations", body: "- " + in.LimitationLine, contract: true},
+		{heading: "Questions / Issues", body: in.ContactLine, contract: true},
+		{heading: "POC Success Criteria", body: "- " + in.placeholder(placeholderSuccessCriteria, in.SuccessCriteria), contract: true},
+		{heading: "Seed Profile", body: fmt.Sprintf("- Active profile: %s\n- %s", profile, statusDetails)},
+		{heading: "Seed Files", body: renderManagedBlock("readme-seed-files", seedFiles)},
+	}, in, rules)
 }
 
-func renderDecisions(in scaffoldInput) string {
-	return fmt.Sprintf(`# Decisions
-
-Capture non-obvious decisions and rationale.
-
-## Entry Format
-
-### YYYY-MM-DD: <Decision title>
+func renderDecisions(in scaffoldInput, rules manifestSnapshot) string {
+	return renderDoc("DECISIONS.md", "# Decisions\n\nCapture non-obvious decisions and rationale.", []docSection{
+		{heading: "Entry Format", body: `### YYYY-MM-DD: <Decision title>
 Context:
 Decision:
-Why not <alternative>: (optional)
-
-## History
-
-### %s: Initialized from Seed
+Why not <alternative>: (optional)`},
+		{heading: "History", body: fmt.Sprintf(`### %s: Initialized from Seed
 Context: Need a lightweight structure to test an idea quickly.
 Decision: Use Seed profile %s to balance speed and context quality.
-Why not heavier process: Added process overhead is not justified at this stage.
-`, in.CreatedDate, in.SeedProfile)
+Why not heavier process: Added process overhead is not justified at this stage.`, in.CreatedDate, in.SeedProfile)},
+	}, in, rules)
 }
 
-func renderTODO() string {
-	return `# TODO
-
-## BLOCKERS
-
-- NONE
-
-## Doing Now
-
-- [ ] Confirm the core demo path works end to end
-- [ ] Replace placeholder run command if still present
-
-## Next Up
-
-- [ ] Add one improvement based on first user feedback
-- [ ] Record non-obvious rationale in DECISIONS.md
-
-## Maybe Later
-
-- [ ] Harden edge-case handling after demo validation
-
-## Done (recent)
-
-- ~~[ ] Scaffolded initial Seed baseline~~
-
-## Won't Do (this iteration)
-
-- Production-level hardening and scaling work
-`
+// todoStarterItems are the starting bodies of the canonical TODO sections.
+var todoStarterItems = map[string]string{
+	"BLOCKERS":                  "- NONE",
+	"Doing Now":                 "- [ ] Confirm the core demo path works end to end\n- [ ] Replace placeholder run command if still present",
+	"Next Up":                   "- [ ] Add one improvement based on first user feedback\n- [ ] Record non-obvious rationale in DECISIONS.md",
+	"Maybe Later":               "- [ ] Harden edge-case handling after demo validation",
+	"Done (recent)":             "- ~~[ ] Scaffolded initial Seed baseline~~",
+	"Won't Do (this iteration)": "- Production-level hardening and scaling work",
 }
 
-func renderContext(in scaffoldInput, profile string) string {
-	keyFiles := []string{
-		"- README.md: project summary, run path, status, limitations",
-		"- DECISIONS.md: non-obvious decisions and rationale",
-		"- TODO.md: active work and short backlog",
-		"- CONTEXT.md: problem, constraints, success criteria, and guardrails",
-		"- AGENTS.md: concise agent operating guide for this repository",
-	}
-	if profile != profileCore {
-		keyFiles = append(keyFiles,
-			"- .seed/manifest.json: local Seed contract snapshot",
-			"- skills/seed-validate/SKILL.md: nuanced drift analysis workflow",
-		)
-	}
-	if profile == profileGuarded {
-		keyFiles = append(keyFiles,
-			"- .seed/seed-test.sh: structural validation entrypoint",
-			"- .seed/hooks/pre-commit: automatic validation trigger",
-			"- .seed/install-hooks.sh: one-time hook installer per clone",
-		)
+// renderTODO lays out todo_sections in manifest order; sections without starter items stay empty.
+func renderTODO(in scaffoldInput, rules manifestSnapshot) string {
+	sections := make([]docSection, 0, len(rules.TodoSections))
+	for _, name := range rules.TodoSections {
+		sections = append(sections, docSection{heading: name, body: todoStarterItems[name]})
 	}
+	return renderDoc("TODO.md", "# TODO", sections, in, rules)
+}
+
+func renderContext(in scaffoldInput, rules manifestSnapshot) string {
+	profile := rules.ActiveProfile
+	keyFiles := requiredFileList(rules, func(path string, note artifactNote) string {
+		return fmt.Sprintf("- %s: %s", path, note.context)
+	})
 
 	llmGuidance := "- Use the profile-specific files listed above as local source of truth."
 	if profile == profileLLM {
@@ -172,58 +214,24 @@ func renderContext(in scaffoldInput, profile string) string {
 		llmGuidance = "- Start with ./.seed/seed-test.sh output; if warnings appear, run skills/seed-validate/SKILL.md."
 	}
 
-	return fmt.Sprintf(`# Context
-
-## Problem Statement
-
-%s
-
-## Constraints
-
-- Timeline:
-- Budget:
-- Must work with:
-
-## POC Success Criteria
-
-- %s
-
-## POC Philosophy
-
-- Keep only artifacts that stay accurate under fast iteration.
-- If a file is unlikely to be maintained when tired, simplify or remove it.
-- Optimize for validated learning speed, not process completeness.
-- Avoid premature contracts/diagrams/roadmaps unless complexity requires them.
-
-## Upgrade Triggers
-
-- Move to OpenSpec/Spec Kit when work becomes phased with explicit milestones and handoffs.
-- Move to OpenSpec/Spec Kit when multiple contributors need stronger contracts and review workflows.
-- Move to OpenSpec/Spec Kit when production commitments require heavier planning and governance.
-
-## Key Files
-
-%s
-
-## Non-Obvious Dependencies
-
-- The seeded repo is self-contained and must not require a path back to the Seed source repo.
-
-## For LLM Agents
-
-- Read README.md first for run/status context.
+	return renderDoc("CONTEXT.md", "# Context", []docSection{
+		{heading: "Problem Statement", body: in.placeholder(placeholderProblemStatement, in.ProblemStatement)},
+		// The Constraints fields come from required_list_fields.
+		{heading: "Constraints"},
+		{heading: "POC Success Criteria", body: "- " + in.placeholder(placeholderSuccessCriteria, in.SuccessCriteria), contract: true},
+		{heading: "POC Philosophy", body: renderManagedBlock("context-poc-philosophy", contextPOCPhilosophy), contract: true},
+		{heading: "Upgrade Triggers", body: renderManagedBlock("context-upgrade-triggers", contextUpgradeTriggers), contract: true},
+		{heading: "Key Files", body: renderManagedBlock("context-key-files", keyFiles), contract: true},
+		{heading: "Non-Obvious Dependencies", body: "- The seeded repo is self-contained and must not require a path back to the Seed source repo."},
+		{heading: "For LLM Agents", body: `- Read README.md first for run/status context.
 - Preserve rationale in DECISIONS.md when making non-obvious choices.
 - Keep TODO.md current by moving completed items into recent done.
-%s
-`,
-		in.ProblemStatement,
-		in.SuccessCriteria,
-		strings.Join(keyFiles, "\n"),
-		llmGuidance,
-	)
+` + llmGuidance},
+	}, in, rules)
 }
 
-func renderAgents(profile string) string {
+func renderAgents(in scaffoldInput, rules manifestSnapshot) string {
+	profile := rules.ActiveProfile
 	workingRules := []string{
 		"- Keep changes small and focused on the user request.",
 		"- Update TODO.md when task state changes.",
@@ -237,42 +245,61 @@ func renderAgents(profile string) string {
 	if profile == profileGuarded {
 		workingRules = append(workingRules,
 			"- Install hooks once per clone: ./.seed/install-hooks.sh",
-			"- Pre-commit runs ./.seed/seed-test.sh automatically.",
+			"- Pre-commit runs ./.seed/seed-test.sh against staged content automatically.",
+			"- Pre-push re-validates each new commit and blocks the push at the first failing one.",
 			"- If SEED_STATUS=skill_recommended, run skills/seed-validate/SKILL.md.",
 		)
 	}
 
-	return fmt.Sprintf(`# AGENTS.md
-
-## Scope
-
-- Applies to the full repository.
-
-## Start Here
-
-- Read README.md for quick start and project status.
+	return renderDoc("AGENTS.md", "# AGENTS.md", []docSection{
+		{heading: "Scope", body: "- Applies to the full repository."},
+		{heading: "Start Here", body: `- Read README.md for quick start and project status.
 - Read CONTEXT.md for constraints and success criteria.
 - Read TODO.md for active priorities.
-- Read DECISIONS.md for non-obvious rationale.
-
-## Working Rules
-
-%s
+- Read DECISIONS.md for non-obvious rationale.`},
+		{heading: "Working Rules", body: renderManagedBlock("agents-working-rules", strings.Join(workingRules, "\n")), contract: true},
+		{heading: "POC Guardrails", body: renderManagedBlock("agents-poc-guardrails", agentsPOCGuardrails), contract: true},
+		{heading: "Upgrade Triggers", body: renderManagedBlock("agents-upgrade-triggers", agentsUpgradeTriggers), contract: true},
+	}, in, rules)
+}
 
-## POC Guardrails
+// placeholder tags starter text so validators can tell it apart from user-authored content.
+func (in scaffoldInput) placeholder(id, text string) string {
+	if !in.Placeholders[id] {
+		return text
+	}
+	return fmt.Sprintf("%s <!-- %s -->", text, placeholderMarker(id, in.CreatedDate))
+}
 
-- Optimize for fast learning and demoable outcomes over completeness.
-- Keep artifacts lightweight; avoid heavy process docs that will go stale.
-- Prefer executable truth in scripts over narrative setup/test instructions.
-- Keep README operational: run path, current status, and immediate caveats.
-- Keep TODO flat and atomic; avoid hierarchy and process overhead.
-- Record only non-obvious decisions; keep entries concise.
+// filler tags text generated from the rules alone, such as stub sections and list fields.
+// Only ids explicitly marked as answered in Placeholders skip the marker.
+func (in scaffoldInput) filler(id, text string) string {
+	if answered, ok := in.Placeholders[id]; ok && !answered {
+		return text
+	}
+	return fmt.Sprintf("%s <!-- %s -->", text, placeholderMarker(id, in.CreatedDate))
+}
 
-## Upgrade Triggers
+// placeholderSlug turns a heading or field name into a placeholder id.
+func placeholderSlug(name string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			builder.WriteRune(r)
+		case builder.Len() > 0 && !strings.HasSuffix(builder.String(), "-"):
+			builder.WriteByte('-')
+		}
+	}
+	return strings.TrimSuffix(builder.String(), "-")
+}
 
-- Propose moving to OpenSpec/Spec Kit when work becomes phased, multi-team, or contract-heavy.
-- Propose moving to OpenSpec/Spec Kit when production hardening needs explicit planning/governance artifacts.
-`, strings.Join(workingRules, "\n"))
+// shellPlaceholder tags starter commands with a shell comment so code blocks stay runnable.
+func (in scaffoldInput) shellPlaceholder(id, command string) string {
+	if !in.Placeholders[id] {
+		return command
+	}
+	return fmt.Sprintf("%s # %s", command, placeholderMarker(id, in.CreatedDate))
 }
 
 func renderIndentedShell(commands []string) string {
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderersFollowManifest(t *testing.T) {
+	requireGit(t)
+	manifest := mustLoadManifest(t)
+
+	// Each variant edits the profile rules; the docs rendered from them must pass the validators reading them.
+	variants := map[string]func(rules *manifestSnapshot){
+		"canonical": func(rules *manifestSnapshot) {},
+		"extended": func(rules *manifestSnapshot) {
+			headings := make([]string, 0, len(rules.RequiredHeadings))
+			for _, spec := range rules.RequiredHeadings {
+				if spec != "README.md::Known Limitations" {
+					headings = append(headings, spec)
+				}
+			}
+			rules.RequiredHeadings = append(headings, "README.md::Architecture", "DECISIONS.md::Open Questions")
+			rules.NonEmptySections = append(append([]string{}, rules.NonEmptySections...), "README.md::Architecture")
+			rules.RequiredListFields = append(append([]string{}, rules.RequiredListFields...), "CONTEXT.md::Constraints::Owner", "CONTEXT.md::Problem Statement::Evidence")
+			rules.TodoSections = append(append([]string{}, rules.TodoSections...), "Icebox")
+		},
+	}
+	for _, profile := range manifest.ProfileOrder {
+		for name, mutate := range variants {
+			t.Run(profile+"/"+name, func(t *testing.T) {
+				target := filepath.Join(t.TempDir(), "repo")
+				mustScaffoldGitRepo(t, target, profile, manifest)
+
+				rules, err := manifestForProfile(manifest, profile)
+				if err != nil {
+					t.Fatalf("profile rules: %v", err)
+				}
+				mutate(&rules)
+				if profile != profileCore {
+					encoded, err := json.MarshalIndent(rules, "", "  ")
+					if err != nil {
+						t.Fatalf("marshal rules: %v", err)
+					}
+					if err := os.WriteFile(filepath.Join(target, ".seed", "manifest.json"), append(encoded, '\n'), 0o644); err != nil {
+						t.Fatalf("write manifest: %v", err)
+					}
+					if profile == profileGuarded {
+						if err := writeRulesFile(target, append(encoded, '\n')); err != nil {
+							t.Fatalf("write rules.tsv: %v", err)
+						}
+					}
+				}
+				in, err := defaultScaffoldInput(target, profile)
+				if err != nil {
+					t.Fatalf("scaffold input: %v", err)
+				}
+				for _, doc := range seedDocs {
+					if err := os.WriteFile(filepath.Join(target, doc), []byte(renderSeedDoc(doc, in, rules)), 0o644); err != nil {
+						t.Fatalf("write %s: %v", doc, err)
+					}
+				}
+
+				for _, spec := range rules.RequiredHeadings {
+					file, heading := splitHeadingSpec(spec)
+					if _, found := sectionLines(mustReadFile(t, filepath.Join(target, file)), heading); !found {
+						t.Fatalf("%s should render required heading %q", file, heading)
+					}
+				}
+				if name == "extended" && strings.Contains(mustReadFile(t, filepath.Join(target, "README.md")), "## Known Limitations") {
+					t.Fatal("README.md should drop a contract section the rules no longer require")
+				}
+				for _, doc := range []string{"README.md", "CONTEXT.md"} {
+					content := mustReadFile(t, filepath.Join(target, doc))
+					for _, file := range rules.RequiredFiles {
+						if !strings.Contains(content, file+"`:") && !strings.Contains(content, "- "+file+":") {
+							t.Fatalf("%s should list required file %s:\n%s", doc, file, content)
+						}
+					}
+				}
+
+				source := worktreeSource{root: target}
+				loaded, _, err := loadRepoRules(source, profile)
+				if err != nil {
+					t.Fatalf("load rules: %v", err)
+				}
+				if profile == profileCore {
+					loaded = rules
+				}
+				report := validateContract(source, loaded, contractOptions{today: time.Now()})
+				for _, finding := range report.Findings {
+					if finding.Severity != severityInfo {
+						t.Fatalf("rendered docs should satisfy their own rules, got %s: %s", finding.Reason, finding.Message)
+					}
+				}
+				if profile == profileGuarded {
+					output, code := runCommandWithExit(t, exec.Command("sh", filepath.Join(target, ".seed", "seed-test.sh")))
+					if code != 0 || parseSeedStatus(output)["SEED_STATUS"] != "ok" {
+						t.Fatalf("seed-test.sh should pass the rendered docs, got exit %d:\n%s", code, output)
+					}
+				}
+			})
+		}
+	}
+}
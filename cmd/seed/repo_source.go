@@ -0,0 +1,153 @@
+package main
+
+// Repo sources let the Go rules read a working tree or a git revision through one interface.
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type repoSource interface {
+	// readFile returns the content of a slash-separated repo-relative path.
+	readFile(path string) ([]byte, error)
+	// isFile reports whether the path is a regular file.
+	isFile(path string) bool
+	// listFiles returns candidate files for content scans as sorted slash-separated paths.
+	listFiles() []string
+}
+
+type worktreeSource struct {
+	root string
+}
+
+func (s worktreeSource) readFile(path string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(s.root, filepath.FromSlash(path)))
+}
+
+func (s worktreeSource) isFile(path string) bool {
+	info, err := os.Stat(filepath.Join(s.root, filepath.FromSlash(path)))
+	return err == nil && !info.IsDir()
+}
+
+// listFiles uses git ls-files inside a work tree so .gitignore applies, and
+// otherwise walks the tree without descending into scanPrunedDirs.
+func (s worktreeSource) listFiles() []string {
+	if files, ok := s.gitFiles(); ok {
+		return files
+	}
+	files := make([]string, 0)
+	_ = filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() && path != s.root && containsString(scanPrunedDirs, entry.Name()) {
+			return filepath.SkipDir
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		relativePath, relErr := filepath.Rel(s.root, path)
+		if relErr != nil {
+			return nil
+		}
+		files = append(files, filepath.ToSlash(relativePath))
+		return nil
+	})
+	sort.Strings(files)
+	return files
+}
+
+// gitFiles lists tracked and untracked, non-ignored files that still exist.
+func (s worktreeSource) gitFiles() ([]string, bool) {
+	if _, err := gitOutput(s.root, "rev-parse", "--is-inside-work-tree"); err != nil {
+		return nil, false
+	}
+	output, err := gitOutput(s.root, "ls-files", "-z", "--cached", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, false
+	}
+	// Unmerged paths are listed once per stage.
+	seen := map[string]bool{}
+	files := make([]string, 0)
+	for _, path := range strings.Split(output, "\x00") {
+		if path == "" || seen[path] || !s.isFile(path) {
+			continue
+		}
+		seen[path] = true
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	return files, true
+}
+
+// gitRevisionSource reads files straight from git objects, so no checkout is needed.
+type gitRevisionSource struct {
+	repoPath string
+	commit   string
+	files    []string
+	present  map[string]bool
+}
+
+func newGitRevisionSource(repoPath, rev string) (*gitRevisionSource, error) {
+	commit, err := gitOutput(repoPath, "rev-parse", "--verify", "-q", rev+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("unknown revision %q", rev)
+	}
+	source := &gitRevisionSource{
+		repoPath: repoPath,
+		commit:   strings.TrimSpace(commit),
+		present:  map[string]bool{},
+	}
+
+	listing, err := gitOutput(repoPath, "ls-tree", "-r", "-z", "--full-tree", source.commit)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range strings.Split(listing, "\x00") {
+		meta, path, found := strings.Cut(entry, "\t")
+		if !found {
+			continue
+		}
+		// Only regular blobs count as files, matching find -type f in seed-test.sh.
+		if fields := strings.Fields(meta); len(fields) == 3 && fields[1] == "blob" && fields[0] != "120000" {
+			source.files = append(source.files, path)
+			source.present[path] = true
+		}
+	}
+	sort.Strings(source.files)
+	return source, nil
+}
+
+func (s *gitRevisionSource) readFile(path string) ([]byte, error) {
+	if !s.present[path] {
+		return nil, fmt.Errorf("%s does not exist at %s: %w", path, shortCommit(s.commit), fs.ErrNotExist)
+	}
+	cmd := exec.Command("git", "-C", s.repoPath, "cat-file", "blob", s.commit+":"+path)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("read %s at %s: %s", path, shortCommit(s.commit), strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
+
+func (s *gitRevisionSource) isFile(path string) bool {
+	return s.present[path]
+}
+
+func (s *gitRevisionSource) listFiles() []string {
+	return s.files
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
@@ -0,0 +1,196 @@
+package main
+
+// Revision validation checks committed history through git objects, so CI and reviewers need no checkout.
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type revisionCommit struct {
+	sha       string
+	parent    string
+	committed time.Time
+	subject   string
+}
+
+// revisionCommits lists commits via git log; the commit date stands in for "today" when aging placeholders.
+// parent is the first parent, empty for a root commit.
+func revisionCommits(repoPath string, args ...string) ([]revisionCommit, error) {
+	output, err := gitOutput(repoPath, append([]string{"log", "--format=%H%x09%P%x09%cI%x09%s"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	commits := make([]revisionCommit, 0)
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		fields := strings.SplitN(line, "\t", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		committed, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("parse commit date of %s: %w", shortCommit(fields[0]), err)
+		}
+		parent, _, _ := strings.Cut(fields[1], " ")
+		commits = append(commits, revisionCommit{sha: fields[0], parent: parent, committed: committed, subject: fields[3]})
+	}
+	return commits, nil
+}
+
+// revisionProfile honours --profile and otherwise infers the profile from the revision's own files.
+func revisionProfile(source repoSource, opts validateLayoutOptions) (string, error) {
+	profile := opts.profile
+	if !opts.profileSet {
+		inferred, err := inferSeedProfile(source)
+		if err != nil {
+			return "", err
+		}
+		profile = inferred
+	}
+	if !validProfiles[profile] {
+		return "", fmt.Errorf("invalid profile: %s (expected core|llm|guarded)", profile)
+	}
+	return profile, nil
+}
+
+// runValidateRevision validates one commit with the Go rules; guarded repos are covered by the parity port.
+func runValidateRevision(opts validateLayoutOptions, out, errOut io.Writer) int {
+	source, err := newGitRevisionSource(opts.repoPath, opts.rev)
+	if err != nil {
+		fmt.Fprintf(errOut, "Failed to read revision: %s\n", err)
+		return 1
+	}
+	commits, err := revisionCommits(opts.repoPath, "-n", "1", source.commit)
+	if err != nil || len(commits) == 0 {
+		fmt.Fprintf(errOut, "Failed to read revision %s: %v\n", opts.rev, err)
+		return 1
+	}
+	profile, err := revisionProfile(source, opts)
+	if err != nil {
+		fmt.Fprintf(errOut, "Failed to infer profile at %s: %s\n", opts.rev, err)
+		return 1
+	}
+
+	fmt.Fprintf(out, "SEED_REVISION=%s\n", source.commit)
+	code := validateLayoutRules(source, profile, commits[0].committed, out, errOut)
+	if code != 0 && code != 2 {
+		return code
+	}
+	if code == 2 {
+		fmt.Fprintln(out, "seed-layout-validation: warnings present, skill recommended")
+	}
+	fmt.Fprintf(out, "seed-layout-validation: ok (profile=%s rev=%s)\n", profile, shortCommit(source.commit))
+	return 0
+}
+
+// runValidateRange validates each commit in A..B oldest first and names the first one that
+// breaks the contract: a failing commit whose first parent passed. Failures inherited from
+// a parent outside the range, such as an already broken range base, are counted but blamed
+// on no commit in the range.
+func runValidateRange(opts validateLayoutOptions, out, errOut io.Writer) int {
+	commits, err := revisionCommits(opts.repoPath, "--reverse", opts.rangeSpec)
+	if err != nil {
+		fmt.Fprintf(errOut, "Failed to list range %s: %s\n", opts.rangeSpec, err)
+		return 1
+	}
+
+	statuses := make(map[string]string, len(commits))
+	var firstBreaking, inheritedFrom *revisionCommit
+	var breakingReport layoutReport
+	failed, warned := 0, 0
+	for i := range commits {
+		commit := commits[i]
+		report, status, err := validateRangeCommit(opts, commit)
+		if err != nil {
+			fmt.Fprintf(errOut, "Failed to %s\n", err)
+			return 1
+		}
+		statuses[commit.sha] = status
+		switch status {
+		case statusFail:
+			failed++
+			parentStatus, parent, err := rangeParentStatus(opts, commit, statuses)
+			if err != nil {
+				fmt.Fprintf(errOut, "Failed to %s\n", err)
+				return 1
+			}
+			if parentStatus != statusFail && firstBreaking == nil {
+				firstBreaking = &commits[i]
+				breakingReport = report
+			}
+			if parentStatus == statusFail && parent != nil && inheritedFrom == nil {
+				inheritedFrom = parent
+			}
+		case statusSkillRecommended:
+			warned++
+		}
+		fmt.Fprintf(out, "%s %s %s\n", shortCommit(commit.sha), status, commit.subject)
+	}
+
+	fmt.Fprintf(out, "SEED_RANGE=%s\n", opts.rangeSpec)
+	fmt.Fprintf(out, "SEED_RANGE_COMMITS=%d\n", len(commits))
+	fmt.Fprintf(out, "SEED_RANGE_FAILED=%d\n", failed)
+	if inheritedFrom != nil {
+		fmt.Fprintf(errOut, "seed-layout-validation: %s %s before the range already fails\n", shortCommit(inheritedFrom.sha), inheritedFrom.subject)
+	}
+	if firstBreaking != nil {
+		for _, finding := range breakingReport.Findings {
+			fmt.Fprintln(errOut, finding.Message)
+		}
+		fmt.Fprintf(out, "SEED_FIRST_BREAKING_COMMIT=%s\n", firstBreaking.sha)
+		fmt.Fprintf(errOut, "seed-layout-validation: first breaking commit %s %s\n", shortCommit(firstBreaking.sha), firstBreaking.subject)
+		return 1
+	}
+
+	fmt.Fprintln(out, "SEED_FIRST_BREAKING_COMMIT=none")
+	if failed > 0 {
+		fmt.Fprintf(errOut, "seed-layout-validation: failed (range=%s commits=%d, every failure predates the range)\n", opts.rangeSpec, len(commits))
+		return 1
+	}
+	if warned > 0 {
+		fmt.Fprintln(out, "seed-layout-validation: warnings present, skill recommended")
+	}
+	fmt.Fprintf(out, "seed-layout-validation: ok (range=%s commits=%d)\n", opts.rangeSpec, len(commits))
+	return 0
+}
+
+// validateRangeCommit runs the Go rules against one commit of a range.
+func validateRangeCommit(opts validateLayoutOptions, commit revisionCommit) (layoutReport, string, error) {
+	source, err := newGitRevisionSource(opts.repoPath, commit.sha)
+	if err != nil {
+		return layoutReport{}, "", fmt.Errorf("read revision: %s", err)
+	}
+	profile, err := revisionProfile(source, opts)
+	if err != nil {
+		return layoutReport{}, "", fmt.Errorf("infer profile at %s: %s", shortCommit(commit.sha), err)
+	}
+	rules, _, err := loadRepoRules(source, profile)
+	if err != nil {
+		return layoutReport{}, "", fmt.Errorf("load Seed rules at %s: %s", shortCommit(commit.sha), err)
+	}
+	report := validateContract(source, rules, contractOptions{today: commit.committed})
+	status, _, _ := report.outcome(rules.WarningsAsErrors)
+	return report, status, nil
+}
+
+// rangeParentStatus returns the status of a commit's first parent. Parents outside the
+// range are validated on demand and also returned; a root commit counts as passing.
+func rangeParentStatus(opts validateLayoutOptions, commit revisionCommit, statuses map[string]string) (string, *revisionCommit, error) {
+	if commit.parent == "" {
+		return statusOK, nil, nil
+	}
+	if status, ok := statuses[commit.parent]; ok {
+		return status, nil, nil
+	}
+	parents, err := revisionCommits(opts.repoPath, "-n", "1", commit.parent)
+	if err != nil || len(parents) == 0 {
+		return "", nil, fmt.Errorf("read parent of %s: %v", shortCommit(commit.sha), err)
+	}
+	_, status, err := validateRangeCommit(opts, parents[0])
+	if err != nil {
+		return "", nil, err
+	}
+	statuses[commit.parent] = status
+	return status, &parents[0], nil
+}
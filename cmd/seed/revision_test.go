@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateRevisionAndRange(t *testing.T) {
+	requireGit(t)
+
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "llm")
+	mustScaffoldProfile(t, target, profileLLM, manifest)
+	runCommandMustSucceed(t, exec.Command("git", "-C", target, "init"))
+	mustCommitAll(t, target, "scaffold")
+	runCommandMustSucceed(t, exec.Command("git", "-C", target, "tag", "base"))
+
+	if err := os.WriteFile(filepath.Join(target, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("write main.go: %v", err)
+	}
+	mustCommitAll(t, target, "add code")
+	if err := os.Remove(filepath.Join(target, "TODO.md")); err != nil {
+		t.Fatalf("remove TODO.md: %v", err)
+	}
+	mustCommitAll(t, target, "drop todo")
+	breaking := strings.TrimSpace(runCommandMustSucceed(t, exec.Command("git", "-C", target, "rev-parse", "HEAD")))
+
+	// The working tree is fixed again; revision checks must still read the committed objects.
+	if err := os.WriteFile(filepath.Join(target, "TODO.md"), []byte("# TODO\n"), 0o644); err != nil {
+		t.Fatalf("restore TODO.md: %v", err)
+	}
+
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	code := runValidateLayout(validateLayoutOptions{repoPath: target, rev: "base"}, &out, &errOut)
+	if code != 0 || !strings.Contains(out.String(), "seed-layout-validation: ok (profile=llm rev=") {
+		t.Fatalf("base revision should pass: exit=%d stdout=%s stderr=%s", code, out.String(), errOut.String())
+	}
+
+	out.Reset()
+	errOut.Reset()
+	code = runValidateLayout(validateLayoutOptions{repoPath: target, rev: "HEAD"}, &out, &errOut)
+	if code != 1 || !strings.Contains(errOut.String(), "Missing required Seed artifact: TODO.md") {
+		t.Fatalf("HEAD revision should fail on TODO.md: exit=%d stdout=%s stderr=%s", code, out.String(), errOut.String())
+	}
+
+	out.Reset()
+	errOut.Reset()
+	code = runValidateLayout(validateLayoutOptions{repoPath: target, rangeSpec: "base..HEAD"}, &out, &errOut)
+	if code != 1 {
+		t.Fatalf("range should fail: exit=%d stdout=%s", code, out.String())
+	}
+	for _, want := range []string{"SEED_RANGE_COMMITS=2", "SEED_RANGE_FAILED=1", "SEED_FIRST_BREAKING_COMMIT=" + breaking} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("range output missing %q: %s", want, out.String())
+		}
+	}
+	if !strings.Contains(errOut.String(), "first breaking commit "+breaking[:12]+" drop todo") {
+		t.Fatalf("range stderr should name the breaking commit: %s", errOut.String())
+	}
+
+	out.Reset()
+	errOut.Reset()
+	code = runValidateLayout(validateLayoutOptions{repoPath: target, rangeSpec: "base..HEAD~1"}, &out, &errOut)
+	if code != 0 || !strings.Contains(out.String(), "SEED_FIRST_BREAKING_COMMIT=none") {
+		t.Fatalf("clean range should pass: exit=%d stdout=%s stderr=%s", code, out.String(), errOut.String())
+	}
+
+	// A range based on a failing commit blames none of the commits that inherit the
+	// failure, only the next one that breaks a passing parent.
+	todo := runCommandMustSucceed(t, exec.Command("git", "-C", target, "show", "base:TODO.md"))
+	if err := os.Remove(filepath.Join(target, "TODO.md")); err != nil {
+		t.Fatalf("remove TODO.md: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatalf("write main.go: %v", err)
+	}
+	mustCommitAll(t, target, "still broken")
+	if err := os.WriteFile(filepath.Join(target, "TODO.md"), []byte(todo), 0o644); err != nil {
+		t.Fatalf("restore TODO.md: %v", err)
+	}
+	mustCommitAll(t, target, "restore todo")
+	if err := os.Remove(filepath.Join(target, "TODO.md")); err != nil {
+		t.Fatalf("remove TODO.md: %v", err)
+	}
+	mustCommitAll(t, target, "drop todo again")
+	rebreaking := strings.TrimSpace(runCommandMustSucceed(t, exec.Command("git", "-C", target, "rev-parse", "HEAD")))
+
+	out.Reset()
+	errOut.Reset()
+	code = runValidateLayout(validateLayoutOptions{repoPath: target, rangeSpec: breaking + "..HEAD~2"}, &out, &errOut)
+	if code != 1 || !strings.Contains(out.String(), "SEED_FIRST_BREAKING_COMMIT=none") ||
+		!strings.Contains(errOut.String(), breaking[:12]+" drop todo before the range already fails") {
+		t.Fatalf("inherited failures should blame no commit in the range: exit=%d stdout=%s stderr=%s", code, out.String(), errOut.String())
+	}
+
+	out.Reset()
+	errOut.Reset()
+	code = runValidateLayout(validateLayoutOptions{repoPath: target, rangeSpec: breaking + "..HEAD"}, &out, &errOut)
+	for _, want := range []string{"SEED_RANGE_COMMITS=3", "SEED_RANGE_FAILED=2", "SEED_FIRST_BREAKING_COMMIT=" + rebreaking} {
+		if code != 1 || !strings.Contains(out.String(), want) {
+			t.Fatalf("range output missing %q: exit=%d stdout=%s stderr=%s", want, code, out.String(), errOut.String())
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package main
+
+// rules.tsv is a flattened copy of .seed/manifest.json that seed-test.sh reads with plain awk.
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	rulesFilePath       = ".seed/rules.tsv"
+	reasonRulesMismatch = "rules_mismatch"
+	reasonMissingRules  = "missing_rules"
+)
+
+const (
+	rulesHeaderPrefix     = "# seed:rules manifest-sha256="
+	overridesHeaderPrefix = "# seed:overrides overrides-sha256="
+	// overridesRulePrefix marks the rules.tsv lines that come from .seed/overrides.json.
+	overridesRulePrefix = "overrides."
+)
+
+// renderRulesTSV flattens a manifest snapshot into "<key><TAB><value>" lines.
+// Nested objects use dotted keys and arrays repeat the key once per item; the
+// header records the manifest checksum so stale copies can be detected.
+// overridesBytes holds .seed/overrides.json, or nil when the repo has none; its
+// checksum goes on a second header line and its rules are appended under
+// "overrides." keys, so seed-test.sh never parses JSON itself.
+func renderRulesTSV(manifestBytes, overridesBytes []byte) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(manifestBytes))
+	decoder.UseNumber()
+	var root map[string]any
+	if err := decoder.Decode(&root); err != nil {
+		return "", fmt.Errorf("parse .seed/manifest.json: %w", err)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(rulesHeaderPrefix + contentHash(string(manifestBytes)) + "\n")
+	if overridesBytes != nil {
+		builder.WriteString(overridesHeaderPrefix + contentHash(string(overridesBytes)) + "\n")
+	}
+	if err := flattenRules(&builder, "", root); err != nil {
+		return "", err
+	}
+	if overridesBytes != nil {
+		builder.WriteString(flattenOverrides(overridesBytes))
+	}
+	return builder.String(), nil
+}
+
+// flattenOverrides renders valid overrides as "overrides."-prefixed rule lines,
+// and invalid ones as a single overrides.invalid line with the problem, which
+// seed-test.sh reports as invalid_overrides.
+func flattenOverrides(overridesBytes []byte) string {
+	var overrides ruleOverrides
+	if err := decodeManifest(overridesBytes, overridesSchemaPath, &overrides); err != nil {
+		return overridesRulePrefix + "invalid\t" + overridesProblem(err) + "\n"
+	}
+	var builder strings.Builder
+	var err error
+	for _, reason := range sortedKeys(overrides.Severities) {
+		if err == nil {
+			err = writeRuleLine(&builder, overridesRulePrefix+"severities."+reason, overrides.Severities[reason])
+		}
+	}
+	for _, spec := range overrides.RequiredHeadings {
+		if err == nil {
+			err = writeRuleLine(&builder, overridesRulePrefix+"required_headings", spec)
+		}
+	}
+	for _, glob := range overrides.ExemptFiles {
+		if err == nil {
+			err = writeRuleLine(&builder, overridesRulePrefix+"exempt_files", glob)
+		}
+	}
+	if err != nil {
+		return overridesRulePrefix + "invalid\t" + overridesProblem(err) + "\n"
+	}
+	return builder.String()
+}
+
+// recordedOverridesHash returns the overrides checksum in a rules.tsv header,
+// or "" when the rules were generated without .seed/overrides.json.
+func recordedOverridesHash(rulesBytes []byte) string {
+	lines := strings.SplitN(string(rulesBytes), "\n", 3)
+	if len(lines) < 2 || !strings.HasPrefix(lines[1], overridesHeaderPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(lines[1], overridesHeaderPrefix)
+}
+
+func flattenRules(builder *strings.Builder, prefix string, object map[string]any) error {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fullKey := prefix + key
+		switch value := object[key].(type) {
+		case nil:
+			continue
+		case map[string]any:
+			if err := flattenRules(builder, fullKey+".", value); err != nil {
+				return err
+			}
+		case []any:
+			for _, item := range value {
+				if err := writeRuleLine(builder, fullKey, item); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := writeRuleLine(builder, fullKey, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeRuleLine(builder *strings.Builder, key string, value any) error {
+	var text string
+	switch value := value.(type) {
+	case string:
+		text = value
+	case json.Number:
+		text = value.String()
+	case bool:
+		text = fmt.Sprintf("%t", value)
+	default:
+		return fmt.Errorf("manifest key %s: only strings, numbers and booleans can be written to %s", key, rulesFilePath)
+	}
+	if strings.ContainsAny(text, "\t\n\r") {
+		return fmt.Errorf("manifest key %s: value %q contains a tab or newline", key, text)
+	}
+	fmt.Fprintf(builder, "%s\t%s\n", key, text)
+	return nil
+}
+
+func writeRulesFile(repoPath string, manifestBytes []byte) error {
+	overridesBytes, err := readOverridesFile(worktreeSource{root: repoPath})
+	if err != nil {
+		return err
+	}
+	rendered, err := renderRulesTSV(manifestBytes, overridesBytes)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(repoPath, filepath.FromSlash(rulesFilePath)), rendered, 0o644); err != nil {
+		return err
+	}
+	return recordLockEntry(repoPath, rulesFilePath, rendered)
+}
+
+// checkMissingRulesFile reports a guarded repo without rules.tsv, which
+// seed-test.sh cannot validate at all, and returns whether it did.
+func checkMissingRulesFile(source repoSource, report *layoutReport) bool {
+	if !source.isFile(".seed/seed-test.sh") || source.isFile(rulesFilePath) {
+		return false
+	}
+	report.addError(reasonMissingRules, rulesFilePath,
+		"Missing Seed rules file: %s (run seed refresh-scripts to generate it from .seed/manifest.json)", rulesFilePath)
+	return true
+}
+
+// checkRulesFile mirrors the checksum checks in seed-test.sh and also compares
+// the rules content, which the shell script cannot regenerate on its own.
+func checkRulesFile(source repoSource, report *layoutReport) {
+	rulesBytes, err := source.readFile(rulesFilePath)
+	if err != nil {
+		return
+	}
+	manifestBytes, err := source.readFile(".seed/manifest.json")
+	if err != nil {
+		return
+	}
+	overridesBytes, err := readOverridesFile(source)
+	if err != nil {
+		return
+	}
+
+	mismatched := false
+	header, _, _ := strings.Cut(string(rulesBytes), "\n")
+	if strings.TrimPrefix(header, rulesHeaderPrefix) != contentHash(string(manifestBytes)) {
+		report.addError(reasonRulesMismatch, rulesFilePath,
+			"Seed rules file %s was generated from a different .seed/manifest.json; run seed refresh-scripts", rulesFilePath)
+		mismatched = true
+	}
+	if !rulesMatchOverrides(rulesBytes, overridesBytes) {
+		report.addError(reasonRulesMismatch, rulesFilePath,
+			"Seed rules file %s was generated from a different %s; run seed refresh-scripts", rulesFilePath, overridesPath)
+		mismatched = true
+	}
+	if mismatched {
+		return
+	}
+	expected, err := renderRulesTSV(manifestBytes, overridesBytes)
+	if err == nil && expected != string(rulesBytes) {
+		report.addError(reasonRulesMismatch, rulesFilePath,
+			"Seed rules file %s does not match .seed/manifest.json; run seed refresh-scripts", rulesFilePath)
+	}
+}
+
+// refreshRulesFile regenerates rules.tsv when it is missing or out of date.
+// The file is derived output, so local edits are replaced without asking.
+func refreshRulesFile(repoPath string, dryRun bool, out io.Writer) error {
+	manifestBytes, err := os.ReadFile(filepath.Join(repoPath, ".seed", "manifest.json"))
+	if err != nil {
+		return fmt.Errorf("read .seed/manifest.json: %w", err)
+	}
+	// seed-test.sh trusts rules.tsv, so a manifest the Go loader would reject is never flattened into it.
+	if err := decodeManifest(manifestBytes, seededSchemaPath, &manifestSnapshot{}); err != nil {
+		return fmt.Errorf("invalid .seed/manifest.json: %w", err)
+	}
+	overridesBytes, err := readOverridesFile(worktreeSource{root: repoPath})
+	if err != nil {
+		return err
+	}
+	expected, err := renderRulesTSV(manifestBytes, overridesBytes)
+	if err != nil {
+		return err
+	}
+	fullPath := filepath.Join(repoPath, filepath.FromSlash(rulesFilePath))
+	current, err := os.ReadFile(fullPath)
+	switch {
+	case err != nil:
+		fmt.Fprintf(out, "%s: missing\n", rulesFilePath)
+	case string(current) == expected:
+		fmt.Fprintf(out, "%s: current\n", rulesFilePath)
+		return nil
+	default:
+		fmt.Fprintf(out, "%s: stale (does not match .seed/manifest.json or .seed/overrides.json)\n", rulesFilePath)
+		fmt.Fprint(out, unifiedDiff("a/"+rulesFilePath, "b/"+rulesFilePath, string(current), expected))
+	}
+	if dryRun {
+		return nil
+	}
+	if err := writeFile(fullPath, expected, 0o644); err != nil {
+		return err
+	}
+	if err := recordLockEntry(repoPath, rulesFilePath, expected); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s: regenerated from .seed/manifest.json\n", rulesFilePath)
+	return nil
+}
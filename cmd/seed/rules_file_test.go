@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRulesFileTracksManifest(t *testing.T) {
+	requireGit(t)
+
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "guarded")
+	mustScaffoldGitRepo(t, target, profileGuarded, manifest)
+
+	rules := mustReadFile(t, filepath.Join(target, ".seed", "rules.tsv"))
+	for _, want := range []string{
+		"required_files\t.seed/rules.tsv\n",
+		"heading_near_miss.max_edit_distance\t2\n",
+		"placeholder_policy.block_guarded_commit\ttrue\n",
+		"staleness.dependency_manifests\tgo.mod\n",
+	} {
+		if !strings.Contains(rules, want) {
+			t.Fatalf("rules.tsv missing %q:\n%s", want, rules)
+		}
+	}
+	report := layoutReport{}
+	checkRulesFile(worktreeSource{root: target}, &report)
+	if len(report.Findings) != 0 {
+		t.Fatalf("fresh rules.tsv should match the manifest: %+v", report.Findings)
+	}
+
+	mustRewriteFile(t, filepath.Join(target, ".seed", "manifest.json"), func(content string) string {
+		return strings.Replace(content, `"max_age_days": 14`, `"max_age_days": 30`, 1)
+	})
+	seedOutput, seedCode := runCommandWithExit(t, exec.Command(filepath.Join(target, ".seed", "seed-test.sh")))
+	if seedCode != 1 || !strings.Contains(seedOutput, "SEED_TRIGGER_REASONS=rules_mismatch") {
+		t.Fatalf("edited manifest should fail seed-test.sh, code=%d:\n%s", seedCode, seedOutput)
+	}
+	report = layoutReport{}
+	checkRulesFile(worktreeSource{root: target}, &report)
+	if len(report.Findings) != 1 || report.Findings[0].Reason != reasonRulesMismatch || !strings.Contains(seedOutput, report.Findings[0].Message) {
+		t.Fatalf("go rules disagree with seed-test: findings=%+v\n%s", report.Findings, seedOutput)
+	}
+
+	var out bytes.Buffer
+	if err := runRefreshScripts(refreshOptions{repoPath: target}, nil, &out); err != nil {
+		t.Fatalf("refresh-scripts: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "+placeholder_policy.max_age_days\t30") {
+		t.Fatalf("refresh should show the regenerated rule:\n%s", out.String())
+	}
+	seedOutput, seedCode = runCommandWithExit(t, exec.Command(filepath.Join(target, ".seed", "seed-test.sh")))
+	if strings.Contains(seedOutput, "rules_mismatch") {
+		t.Fatalf("regenerated rules.tsv should match, code=%d:\n%s", seedCode, seedOutput)
+	}
+
+	// Overrides reach seed-test.sh only through rules.tsv, so editing them needs a refresh.
+	if err := writeFile(filepath.Join(target, ".seed", "overrides.json"), `{"exempt_files": ["docs/**"]}`+"\n", 0o644); err != nil {
+		t.Fatalf("write overrides: %v", err)
+	}
+	seedOutput, seedCode = runCommandWithExit(t, exec.Command(filepath.Join(target, ".seed", "seed-test.sh")))
+	if seedCode != 1 || !strings.Contains(seedOutput, "generated from a different .seed/overrides.json") || strings.Contains(seedOutput, "Override:") {
+		t.Fatalf("unrefreshed overrides should fail seed-test.sh and be ignored, code=%d:\n%s", seedCode, seedOutput)
+	}
+	out.Reset()
+	if err := runRefreshScripts(refreshOptions{repoPath: target}, nil, &out); err != nil {
+		t.Fatalf("refresh-scripts: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "+overrides.exempt_files\tdocs/**") {
+		t.Fatalf("refresh should copy the overrides into rules.tsv:\n%s", out.String())
+	}
+	seedOutput, seedCode = runCommandWithExit(t, exec.Command(filepath.Join(target, ".seed", "seed-test.sh")))
+	if strings.Contains(seedOutput, "rules_mismatch") || !strings.Contains(seedOutput, "Override: findings for docs/** are exempt") {
+		t.Fatalf("refreshed overrides should apply, code=%d:\n%s", seedCode, seedOutput)
+	}
+
+	if err := os.Remove(filepath.Join(target, ".seed", "rules.tsv")); err != nil {
+		t.Fatalf("remove rules.tsv: %v", err)
+	}
+	seedOutput, seedCode = runCommandWithExit(t, exec.Command(filepath.Join(target, ".seed", "seed-test.sh")))
+	if seedCode != 1 || !strings.Contains(seedOutput, "SEED_TRIGGER_REASONS=missing_rules\n") || !strings.Contains(seedOutput, "run seed refresh-scripts") {
+		t.Fatalf("missing rules.tsv should fail seed-test.sh, code=%d:\n%s", seedCode, seedOutput)
+	}
+	report = layoutReport{}
+	if !checkMissingRulesFile(worktreeSource{root: target}, &report) || !strings.Contains(seedOutput, report.Findings[0].Message) {
+		t.Fatalf("go rules disagree with seed-test: findings=%+v\n%s", report.Findings, seedOutput)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+// Scan scope limits which files the misplaced-content check reads, matching scan_scope_filter in seed-test.sh.
+import (
+	"regexp"
+	"strings"
+)
+
+// scanPrunedDirs are skipped at any depth; seed-test.sh prunes the same names.
+var scanPrunedDirs = []string{".git", "node_modules", "vendor", "dist", "build", "target", ".venv"}
+
+var defaultScanInclude = []string{"**/*.md"}
+
+const (
+	scanText     = "text"
+	scanComments = "comments"
+)
+
+type scanScope struct {
+	// Include globs select files read as text; empty means **/*.md.
+	Include []string `json:"include"`
+	// CommentInclude globs select source files whose comments are read for phrase signals.
+	CommentInclude []string `json:"comment_include,omitempty"`
+	// Exclude globs drop candidates on top of scanPrunedDirs.
+	Exclude []string `json:"exclude"`
+
+	// compiled holds the globs compiled by loadRepoRules, so a scan compiles each once.
+	compiled *scopeMatchers
+}
+
+type scopeMatchers struct {
+	include        globMatcher
+	commentInclude globMatcher
+	exclude        globMatcher
+}
+
+// prunedDirMatcher matches any path inside one of scanPrunedDirs.
+var prunedDirMatcher = func() globMatcher {
+	globs := make([]string, 0, len(scanPrunedDirs))
+	for _, dir := range scanPrunedDirs {
+		globs = append(globs, "**/"+dir+"/**")
+	}
+	return compileGlobs(globs)
+}()
+
+// compile returns a copy of the scope that carries its compiled globs.
+func (s scanScope) compile() scanScope {
+	include := s.Include
+	if len(include) == 0 {
+		include = defaultScanInclude
+	}
+	s.compiled = &scopeMatchers{
+		include:        compileGlobs(include),
+		commentInclude: compileGlobs(s.CommentInclude),
+		exclude:        compileGlobs(s.Exclude),
+	}
+	return s
+}
+
+func (s scanScope) matchers() *scopeMatchers {
+	if s.compiled == nil {
+		return s.compile().compiled
+	}
+	return s.compiled
+}
+
+// classify returns scanText, scanComments, or "" for a slash-separated
+// repo-relative path. Paths matched by both include lists are read as text.
+func (s scanScope) classify(path string) string {
+	matchers := s.matchers()
+	switch {
+	case prunedDirMatcher.match(path), matchers.exclude.match(path):
+		return ""
+	case matchers.include.match(path):
+		return scanText
+	case matchers.commentInclude.match(path):
+		return scanComments
+	default:
+		return ""
+	}
+}
+
+// globMatcher is a compiled glob list; it keeps the glob text for messages.
+type globMatcher struct {
+	globs    []string
+	patterns []*regexp.Regexp
+}
+
+func compileGlobs(globs []string) globMatcher {
+	matcher := globMatcher{globs: globs, patterns: make([]*regexp.Regexp, 0, len(globs))}
+	for _, glob := range globs {
+		matcher.patterns = append(matcher.patterns, globRegexp(glob))
+	}
+	return matcher
+}
+
+func (m globMatcher) match(path string) bool {
+	_, ok := m.first(path)
+	return ok
+}
+
+// first returns the first glob in the list that matches path.
+func (m globMatcher) first(path string) (string, bool) {
+	for i, pattern := range m.patterns {
+		if pattern.MatchString(path) {
+			return m.globs[i], true
+		}
+	}
+	return "", false
+}
+
+// matchGlob matches a whole path: * and ? stay within one segment, and ** spans
+// any number of segments, including none when written as **/.
+func matchGlob(glob, path string) bool {
+	return globRegexp(glob).MatchString(path)
+}
+
+func globRegexp(glob string) *regexp.Regexp {
+	runes := []rune(glob)
+	var builder strings.Builder
+	builder.WriteString("^")
+	for i := 0; i < len(runes); i++ {
+		doubleStar := runes[i] == '*' && i+1 < len(runes) && runes[i+1] == '*'
+		switch {
+		case doubleStar && i+2 < len(runes) && runes[i+2] == '/':
+			builder.WriteString("(.*/)?")
+			i += 2
+		case doubleStar:
+			builder.WriteString(".*")
+			i++
+		case runes[i] == '*':
+			builder.WriteString("[^/]*")
+		case runes[i] == '?':
+			builder.WriteString("[^/]")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(runes[i])))
+		}
+	}
+	builder.WriteString("$")
+	return regexp.MustCompile(builder.String())
+}
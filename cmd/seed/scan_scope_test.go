@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchGlob(t *testing.T) {
+	cases := []struct {
+		glob, path string
+		want       bool
+	}{
+		{"**/*.md", "README.md", true},
+		{"**/*.md", "docs/a/b.md", true},
+		{"*.md", "docs/guide.md", false},
+		{"docs/*.md", "docs/a/b.md", false},
+		{"docs/**", "docs/a/b.md", true},
+		{"docs/**/b.md", "docs/b.md", true},
+		{"**/node_modules/**", "web/node_modules/pkg/README.md", true},
+		{"**/node_modules/**", "node_modules.md", false},
+		{"notes?.md", "notes1.md", true},
+		{"notes?.md", "notes/.md", false},
+		{"a+b.md", "a+b.md", true},
+		{"a+b.md", "aab.md", false},
+	}
+	for _, tc := range cases {
+		if got := matchGlob(tc.glob, tc.path); got != tc.want {
+			t.Errorf("matchGlob(%q, %q) = %t, want %t", tc.glob, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestMisplacedContentScanScope(t *testing.T) {
+	root := t.TempDir()
+	misplaced := "# Notes\n\n## Current Status\n\nShipping.\n"
+	for _, file := range []string{"docs/guide.md", "docs/archive/old.md", "notes/plan.txt", "node_modules/pkg/README.md"} {
+		if err := writeFile(filepath.Join(root, filepath.FromSlash(file)), misplaced, 0o644); err != nil {
+			t.Fatalf("write %s: %v", file, err)
+		}
+	}
+	rules := manifestSnapshot{
+		MisplacedContentSignal: []string{"Current Status"},
+		MisplacedContentScope: scanScope{
+			Include: []string{"**/*.md", "notes/*.txt"},
+			Exclude: []string{"docs/archive/**"},
+		},
+	}
+
+	report := layoutReport{}
+	checkMisplacedContent(worktreeSource{root: root}, rules, &report)
+	flagged := make([]string, 0, len(report.Findings))
+	for _, finding := range report.Findings {
+		flagged = append(flagged, finding.File)
+	}
+	// Excluded and pruned paths are skipped; included paths are checked whatever their extension.
+	if want := []string{"docs/guide.md", "notes/plan.txt"}; fmt.Sprint(flagged) != fmt.Sprint(want) {
+		t.Fatalf("flagged %v, want %v: %+v", flagged, want, report.Findings)
+	}
+}
@@ -0,0 +1,533 @@
+package main
+
+// Manifest schemas define both manifest formats; loaders and seed manifest lint check against them.
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	seedassets "seed"
+)
+
+const commandManifest = "manifest"
+
+const manifestLint = "lint"
+
+const (
+	canonicalSchemaPath = "seed-contract/manifest.schema.json"
+	seededSchemaPath    = "seed-contract/seeded-manifest.schema.json"
+	overridesSchemaPath = "seed-contract/overrides.schema.json"
+)
+
+// schemaKeywords lists the JSON Schema keywords schemaValidator understands;
+// the embedded schemas must not use any others.
+var schemaKeywords = map[string]bool{
+	"$schema": true, "$id": true, "$defs": true, "$ref": true, "title": true, "description": true,
+	"type": true, "enum": true, "properties": true, "required": true, "additionalProperties": true,
+	"propertyNames": true, "minProperties": true, "items": true, "minItems": true, "uniqueItems": true,
+	"pattern": true, "minLength": true, "minimum": true, "maximum": true,
+}
+
+type manifestOptions struct {
+	action string
+	path   string
+}
+
+// manifestProblem is one schema or consistency error located by JSON pointer.
+type manifestProblem struct {
+	Pointer string
+	Message string
+}
+
+func (p manifestProblem) String() string {
+	if p.Pointer == "" {
+		return "/: " + p.Message
+	}
+	return p.Pointer + ": " + p.Message
+}
+
+// embeddedSchemas holds the parsed schema files keyed by base name, which is how $ref names them.
+var embeddedSchemas = sync.OnceValues(func() (map[string]map[string]any, error) {
+	docs := make(map[string]map[string]any, 3)
+	for _, schemaPath := range []string{canonicalSchemaPath, seededSchemaPath, overridesSchemaPath} {
+		content, err := seedassets.FS.ReadFile(schemaPath)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", schemaPath, err)
+		}
+		var doc map[string]any
+		if err := json.Unmarshal(content, &doc); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", schemaPath, err)
+		}
+		docs[path.Base(schemaPath)] = doc
+	}
+	return docs, nil
+})
+
+// decodeManifest validates content against a schema and the cross-field rules, then decodes it
+// into target with unknown fields rejected.
+func decodeManifest(content []byte, schemaPath string, target any) error {
+	problems, err := lintManifest(content, schemaPath)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		messages := make([]string, 0, len(problems))
+		for _, problem := range problems {
+			messages = append(messages, problem.String())
+		}
+		return errors.New(strings.Join(messages, "; "))
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(target)
+}
+
+// lintManifest returns every schema problem in content, or the consistency problems once the schema passes.
+// Malformed JSON is returned as an error with its line and column.
+func lintManifest(content []byte, schemaPath string) ([]manifestProblem, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var root any
+	if err := decoder.Decode(&root); err != nil {
+		var syntax *json.SyntaxError
+		if errors.As(err, &syntax) {
+			// Offset counts the byte that failed to parse.
+			line, column := offsetPosition(content, syntax.Offset-1)
+			return nil, fmt.Errorf("line %d, column %d: %s", line, column, syntax.Error())
+		}
+		return nil, err
+	}
+	docs, err := embeddedSchemas()
+	if err != nil {
+		return nil, err
+	}
+	validator := schemaValidator{docs: docs, patterns: map[string]*regexp.Regexp{}}
+	doc := path.Base(schemaPath)
+	validator.validate(root, docs[doc], doc, "")
+	sort.SliceStable(validator.problems, func(i, j int) bool { return validator.problems[i].Pointer < validator.problems[j].Pointer })
+	if len(validator.problems) > 0 {
+		return validator.problems, nil
+	}
+
+	strict := json.NewDecoder(bytes.NewReader(content))
+	strict.DisallowUnknownFields()
+	if schemaPath == overridesSchemaPath {
+		var overrides ruleOverrides
+		if err := strict.Decode(&overrides); err != nil {
+			return nil, fmt.Errorf("decode overrides: %w", err)
+		}
+		return checkSeverityReasons("/severities", overrides.Severities), nil
+	}
+	if schemaPath == canonicalSchemaPath {
+		var manifest canonicalManifest
+		if err := strict.Decode(&manifest); err != nil {
+			return nil, fmt.Errorf("decode manifest: %w", err)
+		}
+		return checkCanonicalConsistency(manifest), nil
+	}
+	var snapshot manifestSnapshot
+	if err := strict.Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+	return checkSnapshotConsistency("", snapshot), nil
+}
+
+// checkCanonicalConsistency covers the profile references a schema cannot express.
+func checkCanonicalConsistency(manifest canonicalManifest) []manifestProblem {
+	problems := make([]manifestProblem, 0)
+	if _, ok := manifest.Profiles[manifest.DefaultProfile]; !ok {
+		problems = append(problems, manifestProblem{"/default_profile", fmt.Sprintf("profile %q is not defined in profiles", manifest.DefaultProfile)})
+	}
+	for i, name := range manifest.ProfileOrder {
+		if _, ok := manifest.Profiles[name]; !ok {
+			problems = append(problems, manifestProblem{fmt.Sprintf("/profile_order/%d", i), fmt.Sprintf("profile %q is not defined in profiles", name)})
+		}
+	}
+	names := make([]string, 0, len(manifest.Profiles))
+	for name := range manifest.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !containsString(manifest.ProfileOrder, name) {
+			problems = append(problems, manifestProblem{"/profile_order", fmt.Sprintf("missing profile %q", name)})
+		}
+		snapshot, err := manifestForProfile(manifest, name)
+		if err != nil {
+			continue
+		}
+		problems = append(problems, checkSnapshotConsistency("/profiles/"+pointerToken(name), snapshot)...)
+	}
+	return problems
+}
+
+// checkSnapshotConsistency checks that specs refer to headings and sections the same rules define.
+func checkSnapshotConsistency(prefix string, rules manifestSnapshot) []manifestProblem {
+	problems := make([]manifestProblem, 0)
+	for i, spec := range rules.HeadingAliases {
+		target := spec[:strings.LastIndex(spec, "::")]
+		if !containsString(rules.RequiredHeadings, target) {
+			problems = append(problems, manifestProblem{fmt.Sprintf("%s/heading_aliases/%d", prefix, i), fmt.Sprintf("%q is not in required_headings", target)})
+		}
+	}
+	problems = append(problems, checkFileRuleShapes(prefix, rules.FileRules)...)
+	problems = append(problems, checkSeverityReasons(prefix+"/rule_severities", rules.RuleSeverities)...)
+	if len(rules.TodoSections) == 0 {
+		sort.SliceStable(problems, func(i, j int) bool { return problems[i].Pointer < problems[j].Pointer })
+		return problems
+	}
+	for i, section := range rules.TodoCheckboxSections {
+		if !containsString(rules.TodoSections, section) {
+			problems = append(problems, manifestProblem{fmt.Sprintf("%s/todo_checkbox_sections/%d", prefix, i), fmt.Sprintf("%q is not in todo_sections", section)})
+		}
+	}
+	for key, section := range map[string]string{"todo_blockers_section": rules.TodoBlockersSection, "todo_done_section": rules.TodoDoneSection} {
+		if section != "" && !containsString(rules.TodoSections, section) {
+			problems = append(problems, manifestProblem{prefix + "/" + key, fmt.Sprintf("%q is not in todo_sections", section)})
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Pointer < problems[j].Pointer })
+	return problems
+}
+
+// schemaValidator checks decoded JSON against the schemaKeywords subset of JSON Schema.
+type schemaValidator struct {
+	docs     map[string]map[string]any
+	patterns map[string]*regexp.Regexp
+	problems []manifestProblem
+}
+
+func (v *schemaValidator) add(pointer, format string, args ...any) {
+	v.problems = append(v.problems, manifestProblem{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *schemaValidator) validate(value any, schema map[string]any, doc, pointer string) {
+	if ref, ok := schema["$ref"].(string); ok {
+		target, targetDoc, err := v.resolve(doc, ref)
+		if err != nil {
+			v.add(pointer, "%s", err)
+			return
+		}
+		v.validate(value, target, targetDoc, pointer)
+		return
+	}
+	if want, ok := schema["type"].(string); ok && !schemaTypeMatches(want, value) {
+		v.add(pointer, "expected %s, got %s", want, jsonTypeName(value))
+		return
+	}
+	if enum, ok := schema["enum"].([]any); ok && !enumContains(enum, value) {
+		allowed := make([]string, 0, len(enum))
+		for _, item := range enum {
+			allowed = append(allowed, jsonText(item))
+		}
+		v.add(pointer, "%s is not one of %s", jsonText(value), strings.Join(allowed, ", "))
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		v.validateObject(typed, schema, doc, pointer)
+	case []any:
+		v.validateArray(typed, schema, doc, pointer)
+	case string:
+		v.validateString(typed, schema, pointer)
+	case json.Number:
+		number, _ := typed.Float64()
+		if minimum, ok := schema["minimum"].(float64); ok && number < minimum {
+			v.add(pointer, "%s is below the minimum %s", typed, jsonText(minimum))
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && number > maximum {
+			v.add(pointer, "%s is above the maximum %s", typed, jsonText(maximum))
+		}
+	}
+}
+
+func (v *schemaValidator) validateObject(object map[string]any, schema map[string]any, doc, pointer string) {
+	properties, _ := schema["properties"].(map[string]any)
+	if required, ok := schema["required"].([]any); ok {
+		for _, key := range required {
+			if _, found := object[key.(string)]; !found {
+				v.add(pointer+"/"+pointerToken(key.(string)), "missing required field")
+			}
+		}
+	}
+	if minimum, ok := schema["minProperties"].(float64); ok && float64(len(object)) < minimum {
+		v.add(pointer, "expected at least %s fields", jsonText(minimum))
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		child := pointer + "/" + pointerToken(key)
+		if names, ok := schema["propertyNames"].(map[string]any); ok {
+			v.validate(key, names, doc, child)
+		}
+		if property, ok := properties[key].(map[string]any); ok {
+			v.validate(object[key], property, doc, child)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case map[string]any:
+			v.validate(object[key], additional, doc, child)
+		case bool:
+			if !additional {
+				v.add(child, "unknown field%s", suggestField(key, properties))
+			}
+		}
+	}
+}
+
+func (v *schemaValidator) validateArray(items []any, schema map[string]any, doc, pointer string) {
+	if minimum, ok := schema["minItems"].(float64); ok && float64(len(items)) < minimum {
+		v.add(pointer, "expected at least %s items", jsonText(minimum))
+	}
+	seen := make(map[string]int, len(items))
+	for i, item := range items {
+		child := fmt.Sprintf("%s/%d", pointer, i)
+		if unique, _ := schema["uniqueItems"].(bool); unique {
+			key := jsonText(item)
+			if first, ok := seen[key]; ok {
+				v.add(child, "duplicate of item %d", first)
+			} else {
+				seen[key] = i
+			}
+		}
+		if itemSchema, ok := schema["items"].(map[string]any); ok {
+			v.validate(item, itemSchema, doc, child)
+		}
+	}
+}
+
+func (v *schemaValidator) validateString(value string, schema map[string]any, pointer string) {
+	if minimum, ok := schema["minLength"].(float64); ok && float64(utf8.RuneCountInString(value)) < minimum {
+		v.add(pointer, "expected at least %s characters", jsonText(minimum))
+	}
+	pattern, ok := schema["pattern"].(string)
+	if !ok {
+		return
+	}
+	compiled, ok := v.patterns[pattern]
+	if !ok {
+		var err error
+		if compiled, err = regexp.Compile(pattern); err != nil {
+			v.add(pointer, "schema pattern %q does not compile: %s", pattern, err)
+			return
+		}
+		v.patterns[pattern] = compiled
+	}
+	if compiled.MatchString(value) {
+		return
+	}
+	if description, ok := schema["description"].(string); ok {
+		v.add(pointer, "%q is not a valid %s", value, description)
+		return
+	}
+	v.add(pointer, "%q does not match %s", value, pattern)
+}
+
+// resolve follows "file#/json/pointer" references; an empty file refers to the current schema.
+func (v *schemaValidator) resolve(doc, ref string) (map[string]any, string, error) {
+	file, fragment, _ := strings.Cut(ref, "#")
+	if file == "" {
+		file = doc
+	}
+	var node any = v.docs[file]
+	if v.docs[file] == nil {
+		return nil, "", fmt.Errorf("schema reference %s names an unknown schema", ref)
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(fragment, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		object, ok := node.(map[string]any)
+		if !ok {
+			return nil, "", fmt.Errorf("schema reference %s does not resolve", ref)
+		}
+		node = object[strings.NewReplacer("~1", "/", "~0", "~").Replace(token)]
+	}
+	target, ok := node.(map[string]any)
+	if !ok {
+		return nil, "", fmt.Errorf("schema reference %s does not resolve", ref)
+	}
+	return target, file, nil
+}
+
+func schemaTypeMatches(want string, value any) bool {
+	switch want {
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := number.Int64()
+		return err == nil
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	}
+	return jsonTypeName(value) == want
+}
+
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number, float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func enumContains(enum []any, value any) bool {
+	for _, item := range enum {
+		if jsonText(item) == jsonText(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonText renders a decoded value as compact JSON for messages and equality checks.
+func jsonText(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+func pointerToken(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// suggestField names the closest known field for likely typos such as required_heading.
+func suggestField(key string, properties map[string]any) string {
+	best, bestDistance := "", 4
+	for name := range properties {
+		if distance := editDistance(key, name); distance < bestDistance || (distance == bestDistance && name < best) {
+			best, bestDistance = name, distance
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf("; did you mean %q?", best)
+}
+
+func offsetPosition(content []byte, offset int64) (int, int) {
+	line, column := 1, 1
+	for _, b := range content[:min(int(offset), len(content))] {
+		if b == '\n' {
+			line++
+			column = 1
+			continue
+		}
+		column++
+	}
+	return line, column
+}
+
+// manifestSchemaFor picks the overrides schema for overrides.json files, the canonical schema for files
+// that define profiles, and the seeded schema otherwise.
+func manifestSchemaFor(name string, content []byte) string {
+	if path.Base(filepath.ToSlash(name)) == path.Base(overridesPath) {
+		return overridesSchemaPath
+	}
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(content, &fields) == nil {
+		if _, ok := fields["profiles"]; ok {
+			return canonicalSchemaPath
+		}
+	}
+	return seededSchemaPath
+}
+
+func parseManifestArgs(opts options, args []string) (options, error) {
+	if len(args) == 0 {
+		return opts, errors.New("missing manifest subcommand (expected lint)")
+	}
+	switch args[0] {
+	case manifestLint:
+		opts.manifest.action = args[0]
+	case "-h", "--help":
+		opts.showHelp = true
+		return opts, nil
+	default:
+		return opts, fmt.Errorf("unknown manifest subcommand: %s", args[0])
+	}
+
+	for _, arg := range args[1:] {
+		switch {
+		case arg == "-h" || arg == "--help":
+			opts.showHelp = true
+		case strings.HasPrefix(arg, "-"):
+			return opts, fmt.Errorf("unknown argument: %s", arg)
+		case opts.manifest.path != "":
+			return opts, errors.New("expected one manifest path")
+		default:
+			opts.manifest.path = arg
+		}
+	}
+	if opts.manifest.path == "" && !opts.showHelp {
+		return opts, errors.New("missing manifest path")
+	}
+	return opts, nil
+}
+
+func printManifestUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: seed manifest lint <path>")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Check a manifest against the schemas in seed-contract/. Files with a profiles key are")
+	fmt.Fprintln(w, "checked as canonical manifests, files named overrides.json as .seed/overrides.json,")
+	fmt.Fprintln(w, "and others as seeded .seed/manifest.json snapshots.")
+	fmt.Fprintln(w, "Each problem is printed with its JSON pointer; exits 1 when any are found.")
+}
+
+func runManifest(opts manifestOptions, out io.Writer) (int, error) {
+	content, err := os.ReadFile(opts.path)
+	if err != nil {
+		return 1, err
+	}
+	schemaPath := manifestSchemaFor(opts.path, content)
+	kind := "seeded manifest"
+	switch schemaPath {
+	case canonicalSchemaPath:
+		kind = "canonical manifest"
+	case overridesSchemaPath:
+		kind = "repo overrides"
+	}
+	problems, err := lintManifest(content, schemaPath)
+	if err != nil {
+		fmt.Fprintf(out, "%s: %s\n", opts.path, err)
+		return 1, nil
+	}
+	for _, problem := range problems {
+		fmt.Fprintf(out, "%s: %s\n", opts.path, problem)
+	}
+	if len(problems) > 0 {
+		fmt.Fprintf(out, "%s: %d problems (%s, schema %s)\n", opts.path, len(problems), kind, schemaPath)
+		return 1, nil
+	}
+	fmt.Fprintf(out, "%s: ok (%s, schema %s)\n", opts.path, kind, schemaPath)
+	return 0, nil
+}
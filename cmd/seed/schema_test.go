@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestManifestSchema(t *testing.T) {
+	docs, err := embeddedSchemas()
+	if err != nil {
+		t.Fatalf("load schemas: %v", err)
+	}
+	// The validator implements a subset of JSON Schema, so the schemas must stay inside it.
+	var walk func(node any, pointer string)
+	walk = func(node any, pointer string) {
+		object, ok := node.(map[string]any)
+		if !ok {
+			return
+		}
+		for key, value := range object {
+			if !schemaKeywords[key] {
+				t.Fatalf("%s uses unsupported schema keyword %q", pointer, key)
+			}
+			switch key {
+			case "properties", "$defs":
+				for name, child := range value.(map[string]any) {
+					walk(child, pointer+"/"+key+"/"+name)
+				}
+			case "items", "additionalProperties", "propertyNames":
+				walk(value, pointer+"/"+key)
+			}
+		}
+	}
+	for name, doc := range docs {
+		walk(doc, name+"#")
+	}
+
+	// Schema properties and struct tags must agree, or strict decoding would reject schema-valid files.
+	validator := schemaValidator{docs: docs}
+	for _, tc := range []struct {
+		ref    string
+		target any
+	}{
+		{"manifest.schema.json#", canonicalManifest{}},
+		{"manifest.schema.json#/$defs/profileRules", profileRules{}},
+		{"seeded-manifest.schema.json#", manifestSnapshot{}},
+		{"overrides.schema.json#", ruleOverrides{}},
+	} {
+		node, _, err := validator.resolve("", tc.ref)
+		if err != nil {
+			t.Fatalf("resolve %s: %v", tc.ref, err)
+		}
+		schemaFields := make([]string, 0)
+		for name := range node["properties"].(map[string]any) {
+			schemaFields = append(schemaFields, name)
+		}
+		structFields := make([]string, 0)
+		for _, field := range reflect.VisibleFields(reflect.TypeOf(tc.target)) {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			structFields = append(structFields, name)
+		}
+		sort.Strings(schemaFields)
+		sort.Strings(structFields)
+		if !reflect.DeepEqual(schemaFields, structFields) {
+			t.Fatalf("%s properties %v do not match %T fields %v", tc.ref, schemaFields, tc.target, structFields)
+		}
+	}
+
+	if _, err := loadCanonicalManifest(); err != nil {
+		t.Fatalf("embedded manifest should pass its schema: %v", err)
+	}
+	manifest := mustLoadManifest(t)
+	if strings.Join(manifest.ProfileOrder, ",") != "core,llm,guarded" {
+		t.Fatalf("profile_order should be loaded, got %v", manifest.ProfileOrder)
+	}
+	var prompt bytes.Buffer
+	if selected, err := chooseProfile(strings.NewReader("3\n"), &prompt, manifest); err != nil || selected != profileGuarded {
+		t.Fatalf("choice 3 should follow profile_order, got %q, %v", selected, err)
+	}
+	if !strings.Contains(prompt.String(), "Select profile [2]: ") {
+		t.Fatalf("prompt should default to the llm position:\n%s", prompt.String())
+	}
+
+	target := t.TempDir()
+	mustScaffoldProfile(t, target, profileLLM, manifest)
+	manifestPath := filepath.Join(target, ".seed", "manifest.json")
+	var lintOut bytes.Buffer
+	if code, err := runManifest(manifestOptions{action: manifestLint, path: manifestPath}, &lintOut); err != nil || code != 0 {
+		t.Fatalf("scaffolded manifest should lint clean, got %d, %v:\n%s", code, err, lintOut.String())
+	}
+
+	mustRewriteFile(t, manifestPath, func(content string) string {
+		content = strings.Replace(content, `"required_headings"`, `"required_heading"`, 1)
+		content = strings.Replace(content, `"README.md::Quick Start::Getting Started"`, `"README.md:Quick Start::Getting Started"`, 1)
+		return strings.Replace(content, `"CONTEXT.md::POC Philosophy::Working Philosophy"`, `"CONTEXT.md::Problem Statement::Problem"`, 1)
+	})
+	lintOut.Reset()
+	code, err := runManifest(manifestOptions{action: manifestLint, path: manifestPath}, &lintOut)
+	if err != nil || code != 1 {
+		t.Fatalf("broken manifest should fail lint, got %d, %v", code, err)
+	}
+	for _, want := range []string{
+		`/heading_aliases/0: "README.md:Quick Start::Getting Started" is not a valid file::heading::alias spec`,
+		`/required_heading: unknown field; did you mean "required_headings"?`,
+		`/required_headings: missing required field`,
+		"3 problems (seeded manifest",
+	} {
+		if !strings.Contains(lintOut.String(), want) {
+			t.Fatalf("lint output should contain %q:\n%s", want, lintOut.String())
+		}
+	}
+	if _, _, err := loadRepoRules(worktreeSource{root: target}, profileLLM); err == nil || !strings.Contains(err.Error(), "unknown field") {
+		t.Fatalf("loadRepoRules should reject unknown fields, got %v", err)
+	}
+
+	// Specs that parse but point at nothing are reported once the schema passes.
+	mustRewriteFile(t, manifestPath, func(content string) string {
+		content = strings.Replace(content, `"required_heading"`, `"required_headings"`, 1)
+		return strings.Replace(content, `"README.md:Quick Start::Getting Started"`, `"README.md::Quick Start::Getting Started"`, 1)
+	})
+	lintOut.Reset()
+	if code, _ := runManifest(manifestOptions{action: manifestLint, path: manifestPath}, &lintOut); code != 1 ||
+		!strings.Contains(lintOut.String(), `/heading_aliases/4: "CONTEXT.md::Problem Statement" is not in required_headings`) {
+		t.Fatalf("alias to an unrequired heading should fail lint, got %d:\n%s", code, lintOut.String())
+	}
+
+	mustRewriteFile(t, manifestPath, func(content string) string {
+		return strings.Replace(content, `"forbid": [`, `"allow": [`, 1)
+	})
+	lintOut.Reset()
+	if code, _ := runManifest(manifestOptions{action: manifestLint, path: manifestPath}, &lintOut); code != 1 ||
+		!strings.Contains(lintOut.String(), `/file_rules/root-plans: rule needs require_any or forbid globs`) {
+		t.Fatalf("rule without require_any or forbid should fail lint, got %d:\n%s", code, lintOut.String())
+	}
+}
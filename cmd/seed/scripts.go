@@ -0,0 +1,319 @@
+package main
+
+// Script stamping lets seeded repos pick up validator fixes without losing local edits.
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const commandRefreshScripts = "refresh-scripts"
+
+// cliVersion is stamped into generated scripts; release builds set it with -ldflags "-X main.cliVersion=<version>".
+var cliVersion = "dev"
+
+const scriptModified = "modified"
+
+// scriptHeaderPattern matches the stamp written on the line after the shebang.
+var scriptHeaderPattern = regexp.MustCompile(`^# seed:script version=(\S+) format=(\S+) sha256=([0-9a-f]{64})$`)
+
+type scriptHeader struct {
+	Version string
+	Format  string
+	Hash    string
+}
+
+type refreshOptions struct {
+	repoPath string
+	dryRun   bool
+	force    bool
+}
+
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// stampScript inserts the version header after the shebang; the hash covers the unstamped content.
+func stampScript(content, formatVersion string) string {
+	shebang, body, _ := strings.Cut(content, "\n")
+	header := fmt.Sprintf("# seed:script version=%s format=%s sha256=%s", cliVersion, formatVersion, contentHash(content))
+	return shebang + "\n" + header + "\n" + body
+}
+
+// parseScriptHeader returns the stamp and the content with the stamp removed.
+func parseScriptHeader(content string) (scriptHeader, string, bool) {
+	shebang, rest, found := strings.Cut(content, "\n")
+	if !found {
+		return scriptHeader{}, content, false
+	}
+	line, body, _ := strings.Cut(rest, "\n")
+	match := scriptHeaderPattern.FindStringSubmatch(line)
+	if match == nil {
+		return scriptHeader{}, content, false
+	}
+	return scriptHeader{Version: match[1], Format: match[2], Hash: match[3]}, shebang + "\n" + body, true
+}
+
+// releasedUnstampedHashes are the SHA-256 hashes of the scripts the 2.0.0 release wrote
+// before scripts carried a version header, so copies from that release can still be updated.
+var releasedUnstampedHashes = map[string]string{
+	".seed/seed-test.sh":     "2b6035638b05f1b3d19eb9f0c9469873b480f6eff730786942708d93a1c85e80",
+	".seed/install-hooks.sh": "d00bb17841ce8ef09d98cd95f6d5c1485cd293c81da5cde26dd24fbbe5bae22d",
+	".seed/hooks/pre-commit": "511056c6c387faaf5677d3c3fe46c9dc8b4ec511b43e9fdff619fc4bfacacb86",
+}
+
+// classifyScript compares an on-disk copy with the embedded script. Unstamped copies
+// count as modified unless they match the current script or the 2.0.0 release.
+func classifyScript(content string, script guardedScript) (string, scriptHeader, string) {
+	header, unstamped, stamped := parseScriptHeader(content)
+	switch {
+	case !stamped && unstamped == script.content:
+		return scriptOutdated, header, "no version header"
+	case !stamped && releasedUnstampedHashes[script.path] == contentHash(unstamped):
+		return scriptOutdated, header, "written before version headers"
+	case !stamped:
+		return scriptModified, header, "no version header"
+	case contentHash(unstamped) != header.Hash:
+		return scriptModified, header, "edited after generation"
+	case header.Hash != contentHash(script.content):
+		return scriptOutdated, header, "generated by seed " + header.Version
+	default:
+		return scriptCurrent, header, ""
+	}
+}
+
+func inspectScript(repoPath string, script guardedScript) (hookScriptState, string) {
+	state := hookScriptState{Path: script.path, State: scriptMissing}
+	fullPath := filepath.Join(repoPath, filepath.FromSlash(script.path))
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		return state, ""
+	}
+	var header scriptHeader
+	state.State, header, state.Detail = classifyScript(string(content), script)
+	state.Version = header.Version
+	if info, err := os.Stat(fullPath); err == nil {
+		state.Executable = info.Mode()&0o111 != 0
+	}
+	return state, string(content)
+}
+
+func writeGuardedScript(repoPath string, script guardedScript, formatVersion string) error {
+	fullPath := filepath.Join(repoPath, filepath.FromSlash(script.path))
+	content := stampScript(script.content, formatVersion)
+	if err := writeFile(fullPath, content, 0o755); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of an existing file.
+	if err := os.Chmod(fullPath, 0o755); err != nil {
+		return fmt.Errorf("chmod %s: %w", script.path, err)
+	}
+	return recordLockEntry(repoPath, script.path, content)
+}
+
+func parseRefreshScriptsArgs(opts options, args []string) (options, error) {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		opts.refresh.repoPath = args[0]
+		args = args[1:]
+	}
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run":
+			opts.refresh.dryRun = true
+		case "--force":
+			opts.refresh.force = true
+		case "-h", "--help":
+			opts.showHelp = true
+		default:
+			return opts, fmt.Errorf("unknown argument: %s", arg)
+		}
+	}
+	return opts, nil
+}
+
+func printRefreshScriptsUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: seed refresh-scripts [repo-path] [--dry-run] [--force]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Update generated guarded scripts to the versions embedded in this CLI.")
+	fmt.Fprintln(w, "Unmodified copies are updated in place; locally modified copies need confirmation.")
+	fmt.Fprintln(w, "Also regenerates .seed/rules.tsv from .seed/manifest.json and .seed/overrides.json.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	fmt.Fprintln(w, "  --dry-run  Show diffs without writing")
+	fmt.Fprintln(w, "  --force    Overwrite locally modified copies without asking")
+}
+
+// runRefreshScripts asks before overwriting modified copies only when confirm is non-nil.
+func runRefreshScripts(opts refreshOptions, confirm io.Reader, out io.Writer) error {
+	if !isGuardedRepo(worktreeSource{root: opts.repoPath}) {
+		return fmt.Errorf("%s is not a guarded Seed repo; only guarded repos have generated scripts", opts.repoPath)
+	}
+	manifest, err := loadCanonicalManifest()
+	if err != nil {
+		return err
+	}
+
+	var answers *bufio.Reader
+	if confirm != nil {
+		answers = bufio.NewReader(confirm)
+	}
+	refused := make([]string, 0)
+	for _, script := range guardedScripts {
+		state, current := inspectScript(opts.repoPath, script)
+		next := stampScript(script.content, manifest.SeedFormatVersion)
+		detail := ""
+		if state.Detail != "" {
+			detail = " (" + state.Detail + ")"
+		}
+
+		switch state.State {
+		case scriptCurrent:
+			fmt.Fprintf(out, "%s: current (seed %s)\n", script.path, state.Version)
+			continue
+		case scriptMissing:
+			fmt.Fprintf(out, "%s: missing\n", script.path)
+		default:
+			fmt.Fprintf(out, "%s: %s%s\n", script.path, state.State, detail)
+			fmt.Fprint(out, unifiedDiff("a/"+script.path, "b/"+script.path, current, next))
+		}
+		if opts.dryRun {
+			continue
+		}
+
+		if state.State == scriptModified && !opts.force {
+			if answers == nil || !confirmOverwrite(answers, out, script.path) {
+				fmt.Fprintf(out, "%s: kept local changes\n", script.path)
+				refused = append(refused, script.path)
+				continue
+			}
+		}
+		if err := writeGuardedScript(opts.repoPath, script, manifest.SeedFormatVersion); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s: updated to seed %s\n", script.path, cliVersion)
+	}
+	if err := refreshRulesFile(opts.repoPath, opts.dryRun, out); err != nil {
+		return err
+	}
+
+	if len(refused) > 0 {
+		return fmt.Errorf("refused to overwrite locally modified scripts: %s (rerun with --force to replace them)", strings.Join(refused, ", "))
+	}
+	return nil
+}
+
+func confirmOverwrite(answers *bufio.Reader, out io.Writer, path string) bool {
+	fmt.Fprintf(out, "Overwrite locally modified %s? [y/N]: ", path)
+	line, err := answers.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
+
+// unifiedDiff renders a line diff with three lines of context; it returns "" when the inputs match.
+func unifiedDiff(fromName, toName, from, to string) string {
+	a := strings.SplitAfter(from, "\n")
+	b := strings.SplitAfter(to, "\n")
+	if a[len(a)-1] == "" {
+		a = a[:len(a)-1]
+	}
+	if b[len(b)-1] == "" {
+		b = b[:len(b)-1]
+	}
+
+	// lcs[i][j] is the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type diffLine struct {
+		op   byte
+		text string
+		aPos int
+		bPos int
+	}
+	lines := make([]diffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i], i, j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', a[i], i, j})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j], i, j})
+			j++
+		}
+	}
+
+	const context = 3
+	var builder strings.Builder
+	for start := 0; start < len(lines); {
+		if lines[start].op == ' ' {
+			start++
+			continue
+		}
+		first := max(start-context, 0)
+		last := start
+		for k := start; k < len(lines) && k <= last+2*context; k++ {
+			if lines[k].op != ' ' {
+				last = k
+			}
+		}
+		end := min(last+context+1, len(lines))
+
+		aCount, bCount := 0, 0
+		for _, line := range lines[first:end] {
+			if line.op != '+' {
+				aCount++
+			}
+			if line.op != '-' {
+				bCount++
+			}
+		}
+		if builder.Len() == 0 {
+			fmt.Fprintf(&builder, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		// An empty side is numbered from the line before the hunk, as in diff -u.
+		aStart, bStart := lines[first].aPos+1, lines[first].bPos+1
+		if aCount == 0 {
+			aStart--
+		}
+		if bCount == 0 {
+			bStart--
+		}
+		fmt.Fprintf(&builder, "@@ -%d,%d +%d,%d @@\n", aStart, aCount, bStart, bCount)
+		for _, line := range lines[first:end] {
+			builder.WriteByte(line.op)
+			builder.WriteString(line.text)
+			if !strings.HasSuffix(line.text, "\n") {
+				builder.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		start = end
+	}
+	return builder.String()
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRefreshScripts(t *testing.T) {
+	requireGit(t)
+
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "guarded")
+	mustScaffoldGitRepo(t, target, profileGuarded, manifest)
+
+	seedTest := filepath.Join(target, ".seed", "seed-test.sh")
+	header, _, ok := parseScriptHeader(mustReadFile(t, seedTest))
+	if !ok || header.Version != cliVersion || header.Format != manifest.SeedFormatVersion || header.Hash != contentHash(guardedSeedTestScript) {
+		t.Fatalf("unexpected script header: %+v ok=%t", header, ok)
+	}
+
+	mustRewriteFile(t, seedTest, func(string) string {
+		return stampScript(guardedSeedTestScript+"# older release\n", manifest.SeedFormatVersion)
+	})
+	prePush := filepath.Join(target, ".seed", "hooks", "pre-push")
+	mustRewriteFile(t, prePush, func(content string) string {
+		return content + "# local tweak\n"
+	})
+
+	var out bytes.Buffer
+	err := runRefreshScripts(refreshOptions{repoPath: target}, nil, &out)
+	if err == nil || !strings.Contains(err.Error(), ".seed/hooks/pre-push") {
+		t.Fatalf("expected modified pre-push to be refused, got %v\n%s", err, out.String())
+	}
+	for _, want := range []string{
+		".seed/seed-test.sh: outdated",
+		"-# older release",
+		".seed/seed-test.sh: updated to seed " + cliVersion,
+		".seed/hooks/pre-push: modified (edited after generation)",
+		"-# local tweak",
+		".seed/hooks/pre-push: kept local changes",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("refresh output missing %q:\n%s", want, out.String())
+		}
+	}
+	if state, _ := inspectScript(target, guardedScripts[0]); state.State != scriptCurrent {
+		t.Fatalf("outdated script should be refreshed: %+v", state)
+	}
+	if !strings.Contains(mustReadFile(t, prePush), "# local tweak") {
+		t.Fatal("modified script must be kept without confirmation")
+	}
+
+	out.Reset()
+	if err := runRefreshScripts(refreshOptions{repoPath: target}, strings.NewReader("y\n"), &out); err != nil {
+		t.Fatalf("confirmed refresh: %v\n%s", err, out.String())
+	}
+	if state, _ := inspectScript(target, guardedScripts[2]); state.State != scriptCurrent {
+		t.Fatalf("confirmed overwrite should leave pre-push current: %+v", state)
+	}
+
+	// Scripts from the 2.0.0 scaffold have no header but are Seed's own, so they update without asking.
+	for _, path := range []string{"seed-test.sh", "install-hooks.sh", "hooks/pre-commit"} {
+		legacy := mustReadFile(t, filepath.Join("testdata", "guarded-2.0.0", filepath.FromSlash(path)))
+		mustRewriteFile(t, filepath.Join(target, ".seed", filepath.FromSlash(path)), func(string) string { return legacy })
+	}
+	out.Reset()
+	if err := runRefreshScripts(refreshOptions{repoPath: target}, nil, &out); err != nil {
+		t.Fatalf("refresh of 2.0.0 scripts: %v\n%s", err, out.String())
+	}
+	for _, want := range []string{
+		".seed/seed-test.sh: outdated (written before version headers)",
+		".seed/install-hooks.sh: outdated (written before version headers)",
+		".seed/hooks/pre-commit: outdated (written before version headers)",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("refresh output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	// Any other unstamped copy may be hand-edited, so it is not replaced without confirmation.
+	edited := mustReadFile(t, filepath.Join("testdata", "guarded-2.0.0", "seed-test.sh")) + "# local tweak\n"
+	mustRewriteFile(t, seedTest, func(string) string { return edited })
+	out.Reset()
+	if err := runRefreshScripts(refreshOptions{repoPath: target}, nil, &out); err == nil || !strings.Contains(err.Error(), "refused to overwrite locally modified scripts") {
+		t.Fatalf("refresh should refuse an edited unstamped script, got %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), ".seed/seed-test.sh: modified (no version header)") || mustReadFile(t, seedTest) != edited {
+		t.Fatalf("edited unstamped script must be kept:\n%s", out.String())
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+// Staleness checks use git history to flag Seed docs that no longer keep pace with the code.
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const (
+	reasonStaleStatus            = "stale_status"
+	reasonStaleTodo              = "stale_todo"
+	reasonUndocumentedDependency = "undocumented_dependency_change"
+	reasonStalenessSkipped       = "staleness_skipped"
+)
+
+type stalenessRules struct {
+	// StatusMaxCodeCommits bounds code commits since README.md "Current Status" changed; zero disables it.
+	StatusMaxCodeCommits int `json:"status_max_code_commits"`
+	// TodoMaxCommits bounds commits since TODO.md changed; zero disables it.
+	TodoMaxCommits int `json:"todo_max_commits"`
+	// DependencyManifests are paths whose changes should come with a DECISIONS.md entry.
+	DependencyManifests []string `json:"dependency_manifests"`
+	// MaxDependencyCommitsWithoutDecision tolerates this many dependency commits after the last DECISIONS.md edit.
+	MaxDependencyCommitsWithoutDecision int `json:"max_dependency_commits_without_decision"`
+}
+
+// seedDocPathspecs exclude Seed-owned files when counting code churn.
+var seedDocPathspecs = []string{".", ":(exclude)*.md", ":(exclude).seed", ":(exclude)skills"}
+
+func checkStaleness(repoPath string, rules manifestSnapshot, report *layoutReport) {
+	if _, err := exec.LookPath("git"); err != nil {
+		report.addInfo(reasonStalenessSkipped, "", "Note: staleness checks skipped: git is not installed")
+		return
+	}
+	if _, err := gitOutput(repoPath, "rev-parse", "--verify", "-q", "HEAD"); err != nil {
+		report.addInfo(reasonStalenessSkipped, "", "Note: staleness checks skipped: %s has no git history", repoPath)
+		return
+	}
+	limits := rules.Staleness
+
+	if limits.StatusMaxCodeCommits > 0 {
+		if sha, ok := lastSectionEdit(repoPath, "README.md", "Current Status"); ok {
+			churn := gitCount(repoPath, append([]string{"rev-list", "--count", sha + "..HEAD", "--"}, seedDocPathspecs...)...)
+			if churn > limits.StatusMaxCodeCommits {
+				report.addWarning(reasonStaleStatus, "README.md",
+					"README.md \"Current Status\" has not changed in %d code commits (limit %d); refresh it to match the code",
+					churn, limits.StatusMaxCodeCommits)
+			}
+		}
+	}
+
+	if limits.TodoMaxCommits > 0 {
+		if sha, ok := lastPathEdit(repoPath, "TODO.md"); ok {
+			commits := gitCount(repoPath, "rev-list", "--count", sha+"..HEAD")
+			if commits > limits.TodoMaxCommits {
+				report.addWarning(reasonStaleTodo, "TODO.md",
+					"TODO.md has not changed in %d commits (limit %d); move finished work to Done (recent)",
+					commits, limits.TodoMaxCommits)
+			}
+		}
+	}
+
+	if len(limits.DependencyManifests) > 0 {
+		since := "HEAD"
+		if sha, ok := lastPathEdit(repoPath, "DECISIONS.md"); ok {
+			since = sha + "..HEAD"
+		}
+		args := append([]string{"rev-list", "--count", since, "--"}, limits.DependencyManifests...)
+		commits := gitCount(repoPath, args...)
+		if commits > limits.MaxDependencyCommitsWithoutDecision {
+			changed, _ := gitOutput(repoPath, append([]string{"log", "--format=", "--name-only", since, "--"}, limits.DependencyManifests...)...)
+			report.addWarning(reasonUndocumentedDependency, "DECISIONS.md",
+				"%d commit(s) changed dependency manifests (%s) since the last DECISIONS.md entry; record the rationale",
+				commits, strings.Join(uniqueLines(changed), ", "))
+		}
+	}
+}
+
+// lastSectionEdit finds the newest commit that touched a section as it exists at HEAD.
+func lastSectionEdit(repoPath, file, heading string) (string, bool) {
+	content, err := gitOutput(repoPath, "show", "HEAD:"+file)
+	if err != nil {
+		return "", false
+	}
+	start, end := 0, 0
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if start == 0 {
+			if line == "## "+heading {
+				start, end = i+1, i+1
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "## ") || strings.HasPrefix(line, "# ") {
+			break
+		}
+		if strings.TrimSpace(line) != "" {
+			end = i + 1
+		}
+	}
+	if start == 0 {
+		return "", false
+	}
+	sha, err := gitOutput(repoPath, "log", "-n", "1", "-s", "--format=%H", "-L", fmt.Sprintf("%d,%d:%s", start, end, file))
+	sha = strings.TrimSpace(sha)
+	return sha, err == nil && sha != ""
+}
+
+func lastPathEdit(repoPath, path string) (string, bool) {
+	sha, err := gitOutput(repoPath, "log", "-n", "1", "--format=%H", "--", path)
+	sha = strings.TrimSpace(sha)
+	return sha, err == nil && sha != ""
+}
+
+func gitCount(repoPath string, args ...string) int {
+	output, err := gitOutput(repoPath, args...)
+	if err != nil {
+		return 0
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(output))
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+func gitOutput(repoPath string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", repoPath}, args...)...)
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return string(output), nil
+}
+
+func uniqueLines(output string) []string {
+	seen := map[string]bool{}
+	lines := make([]string, 0)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || seen[line] {
+			continue
+		}
+		seen[line] = true
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStalenessChecks(t *testing.T) {
+	requireGit(t)
+
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "llm")
+	mustScaffoldProfile(t, target, profileLLM, manifest)
+	runCommandMustSucceed(t, exec.Command("git", "-C", target, "init"))
+	mustCommitAll(t, target, "scaffold")
+
+	rules, _, err := loadRepoRules(worktreeSource{root: target}, profileLLM)
+	if err != nil {
+		t.Fatalf("load repo rules: %v", err)
+	}
+	rules.Staleness.StatusMaxCodeCommits = 2
+	rules.Staleness.TodoMaxCommits = 3
+
+	report := layoutReport{}
+	checkStaleness(target, rules, &report)
+	if len(report.Findings) != 0 {
+		t.Fatalf("fresh history should not be stale: %v", report.Findings)
+	}
+
+	for _, name := range []string{"a.go", "b.go", "c.go"} {
+		if err := os.WriteFile(filepath.Join(target, name), []byte("package main\n"), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+		mustCommitAll(t, target, "add "+name)
+	}
+	if err := os.WriteFile(filepath.Join(target, "go.mod"), []byte("module demo\n"), 0o644); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+	mustCommitAll(t, target, "add go.mod")
+
+	report = layoutReport{}
+	checkStaleness(target, rules, &report)
+	_, reasons, _ := report.outcome(false)
+	want := "stale_status,stale_todo,undocumented_dependency_change"
+	if strings.Join(reasons, ",") != want || report.count(severityWarning) != 3 {
+		t.Fatalf("unexpected staleness findings: reasons=%v findings=%v", reasons, report.Findings)
+	}
+
+	mustRewriteFile(t, filepath.Join(target, "README.md"), func(content string) string {
+		return strings.Replace(content, "POC - scaffolded and ready for implementation.", "Demo path works end to end.", 1)
+	})
+	mustCommitAll(t, target, "refresh status")
+
+	report = layoutReport{}
+	checkStaleness(target, rules, &report)
+	_, reasons, _ = report.outcome(false)
+	if strings.Join(reasons, ",") != "stale_todo,undocumented_dependency_change" {
+		t.Fatalf("status refresh should clear stale_status: %v", report.Findings)
+	}
+}
@@ -0,0 +1,34 @@
+#!/usr/bin/env sh
+set -eu
+
+repo_root=$(CDPATH= cd -- "$(dirname -- "$0")/.." && pwd)
+cd "$repo_root"
+
+if ! git rev-parse --is-inside-work-tree >/dev/null 2>&1; then
+  printf 'Error: not inside a git repository. Run git init (or clone) first.\n' >&2
+  exit 1
+fi
+
+if [ ! -f ".seed/hooks/pre-commit" ]; then
+  printf 'Error: missing .seed/hooks/pre-commit\n' >&2
+  exit 1
+fi
+
+if [ ! -f ".seed/seed-test.sh" ]; then
+  printf 'Error: missing .seed/seed-test.sh\n' >&2
+  exit 1
+fi
+
+chmod +x .seed/hooks/pre-commit .seed/seed-test.sh
+
+git config core.hooksPath .seed/hooks
+
+configured=$(git config --local --get core.hooksPath || true)
+if [ "$configured" != ".seed/hooks" ]; then
+  printf 'Error: failed to configure core.hooksPath\n' >&2
+  exit 1
+fi
+
+printf 'Seed hooks installed.\n'
+printf 'core.hooksPath=%s\n' "$configured"
+printf 'pre-commit now runs ./.seed/seed-test.sh\n'
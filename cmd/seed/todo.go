@@ -0,0 +1,221 @@
+package main
+
+// TODO parsing keeps TODO.md flat and predictable for both humans and agents.
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const commandTodo = "todo"
+
+const (
+	reasonTodoSectionMissing   = "todo_section_missing"
+	reasonTodoSectionOrder     = "todo_section_order"
+	reasonTodoCheckbox         = "todo_checkbox"
+	reasonTodoDoneOverflow     = "todo_done_overflow"
+	reasonTodoBlockersConflict = "todo_blockers_contradiction"
+)
+
+var (
+	todoCheckboxPattern = regexp.MustCompile(`^[-*] \[[ xX]\] [^[:space:]]`)
+	todoItemPattern     = regexp.MustCompile(`^\[([ xX])\] ?(.*)$`)
+)
+
+type todoDocument struct {
+	Sections []todoSection `json:"sections"`
+}
+
+type todoSection struct {
+	Name  string     `json:"name"`
+	Line  int        `json:"line"`
+	Items []todoItem `json:"items"`
+}
+
+type todoItem struct {
+	Text     string `json:"text"`
+	Line     int    `json:"line"`
+	Checkbox bool   `json:"checkbox"`
+	Done     bool   `json:"done"`
+	// raw keeps the original line for syntax checks.
+	raw string
+}
+
+// parseTodo collects top-level "- " or "* " items under each "## " section.
+func parseTodo(content string) todoDocument {
+	doc := todoDocument{Sections: make([]todoSection, 0)}
+	for index, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			doc.Sections = append(doc.Sections, todoSection{
+				Name:  strings.TrimPrefix(line, "## "),
+				Line:  index + 1,
+				Items: make([]todoItem, 0),
+			})
+			continue
+		}
+		if strings.HasPrefix(line, "# ") {
+			continue
+		}
+		if len(doc.Sections) == 0 || !(strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ")) {
+			continue
+		}
+		current := &doc.Sections[len(doc.Sections)-1]
+		current.Items = append(current.Items, parseTodoItem(line, index+1))
+	}
+	return doc
+}
+
+func parseTodoItem(line string, lineNumber int) todoItem {
+	text := strings.TrimSpace(line[2:])
+	item := todoItem{Line: lineNumber, raw: line}
+	if strings.HasPrefix(text, "~~") && strings.HasSuffix(text, "~~") && len(text) >= 4 {
+		text = strings.TrimSpace(text[2 : len(text)-2])
+		item.Done = true
+	}
+	if match := todoItemPattern.FindStringSubmatch(text); match != nil {
+		item.Checkbox = true
+		item.Done = item.Done || match[1] != " "
+		text = strings.TrimSpace(match[2])
+	}
+	item.Text = text
+	return item
+}
+
+func checkTodo(source repoSource, rules manifestSnapshot, report *layoutReport) {
+	if rules.TodoFile == "" {
+		return
+	}
+	content, err := source.readFile(rules.TodoFile)
+	if err != nil {
+		return
+	}
+	file := rules.TodoFile
+	doc := parseTodo(string(content))
+
+	present := map[string]bool{}
+	for _, section := range doc.Sections {
+		present[section.Name] = true
+	}
+	for _, name := range rules.TodoSections {
+		if !present[name] {
+			report.addError(reasonTodoSectionMissing, file, "Missing TODO section \"%s\" in %s", name, file)
+		}
+	}
+
+	highest := -1
+	for _, section := range doc.Sections {
+		position := indexOfString(rules.TodoSections, section.Name)
+		if position < 0 {
+			continue
+		}
+		if position < highest {
+			report.addWarning(reasonTodoSectionOrder, file,
+				"TODO section \"%s\" in %s:%d is out of order (expected before \"%s\")",
+				section.Name, file, section.Line, rules.TodoSections[highest])
+			continue
+		}
+		highest = position
+	}
+
+	for _, section := range doc.Sections {
+		switch {
+		case section.Name == rules.TodoBlockersSection:
+			hasNone, others := false, 0
+			for _, item := range section.Items {
+				if strings.EqualFold(item.Text, "NONE") {
+					hasNone = true
+				} else {
+					others++
+				}
+			}
+			if hasNone && others > 0 {
+				report.addWarning(reasonTodoBlockersConflict, file,
+					"TODO section \"%s\" in %s:%d says NONE but lists %d other item(s)", section.Name, file, section.Line, others)
+			}
+		case containsString(rules.TodoCheckboxSections, section.Name):
+			for _, item := range section.Items {
+				if !todoCheckboxPattern.MatchString(item.raw) {
+					report.addWarning(reasonTodoCheckbox, file,
+						"Malformed TODO item in %s:%d under \"%s\": expected \"- [ ] <task>\"", file, item.Line, section.Name)
+				}
+			}
+		case section.Name == rules.TodoDoneSection:
+			if rules.TodoDoneLimit > 0 && len(section.Items) > rules.TodoDoneLimit {
+				report.addWarning(reasonTodoDoneOverflow, file,
+					"TODO section \"%s\" in %s:%d has %d items (limit %d); archive the oldest %d to git history or DECISIONS.md",
+					section.Name, file, section.Line, len(section.Items), rules.TodoDoneLimit, len(section.Items)-rules.TodoDoneLimit)
+			}
+		}
+	}
+}
+
+func indexOfString(values []string, target string) int {
+	for i, value := range values {
+		if value == target {
+			return i
+		}
+	}
+	return -1
+}
+
+func parseTodoArgs(opts options, args []string) (options, error) {
+	if len(args) == 0 {
+		return opts, errors.New("missing todo subcommand (expected list)")
+	}
+	switch args[0] {
+	case "list":
+	case "-h", "--help":
+		opts.showHelp = true
+		return opts, nil
+	default:
+		return opts, fmt.Errorf("unknown todo subcommand: %s", args[0])
+	}
+	return parseDocListArgs(opts, args[1:])
+}
+
+func printTodoUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: seed todo list [repo-path] [--json]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "List sections and items from the repo's todo_file, TODO.md by default.")
+}
+
+func runTodoList(opts docListOptions, out io.Writer) error {
+	file, err := docListFile(opts.repoPath, "todo_file", func(rules manifestSnapshot) string { return rules.TodoFile })
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(filepath.Join(opts.repoPath, filepath.FromSlash(file)))
+	if err != nil {
+		return fmt.Errorf("read %s: %w", file, err)
+	}
+	doc := parseTodo(string(content))
+
+	if opts.jsonOutput {
+		encoded, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal todo: %w", err)
+		}
+		fmt.Fprintln(out, string(encoded))
+		return nil
+	}
+
+	for _, section := range doc.Sections {
+		fmt.Fprintf(out, "%s (%d)\n", section.Name, len(section.Items))
+		for _, item := range section.Items {
+			marker := "-"
+			switch {
+			case item.Checkbox && item.Done:
+				marker = "[x]"
+			case item.Checkbox:
+				marker = "[ ]"
+			}
+			fmt.Fprintf(out, "  %s %s\n", marker, item.Text)
+		}
+	}
+	return nil
+}
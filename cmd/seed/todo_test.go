@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTodoRules(t *testing.T) {
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "llm")
+	mustScaffoldProfile(t, target, profileLLM, manifest)
+
+	rules, _, err := loadRepoRules(worktreeSource{root: target}, profileLLM)
+	if err != nil {
+		t.Fatalf("load repo rules: %v", err)
+	}
+	report := layoutReport{}
+	checkTodo(worktreeSource{root: target}, rules, &report)
+	if len(report.Findings) != 0 {
+		t.Fatalf("fresh TODO.md should pass: %v", report.Findings)
+	}
+
+	mustRewriteFile(t, filepath.Join(target, "TODO.md"), func(content string) string {
+		content = strings.Replace(content, "- NONE\n", "- NONE\n- Waiting on API keys\n", 1)
+		content = strings.Replace(content, "- [ ] Add one improvement", "- [] Add one improvement", 1)
+		content = strings.Replace(content, "## Maybe Later\n", "## Someday\n", 1)
+		return strings.Replace(content, "- ~~[ ] Scaffolded initial Seed baseline~~\n",
+			strings.Repeat("- [x] Shipped a slice\n", 6), 1)
+	})
+
+	report = layoutReport{}
+	checkTodo(worktreeSource{root: target}, rules, &report)
+	_, reasons, _ := report.outcome(false)
+	want := "todo_section_missing,todo_blockers_contradiction,todo_checkbox,todo_done_overflow"
+	if strings.Join(reasons, ",") != want {
+		t.Fatalf("unexpected todo findings: reasons=%v findings=%v", reasons, report.Findings)
+	}
+
+	var out bytes.Buffer
+	if err := runTodoList(docListOptions{repoPath: target, jsonOutput: true}, &out); err != nil {
+		t.Fatalf("todo list: %v", err)
+	}
+	var doc todoDocument
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil || len(doc.Sections) != 6 {
+		t.Fatalf("unexpected todo json (%v): %s", err, out.String())
+	}
+	done := doc.Sections[4]
+	if done.Name != "Done (recent)" || len(done.Items) != 6 || !done.Items[0].Done || done.Items[0].Text != "Shipped a slice" {
+		t.Fatalf("unexpected done section: %+v", done)
+	}
+
+	if err := os.Rename(filepath.Join(target, "TODO.md"), filepath.Join(target, "TASKS.md")); err != nil {
+		t.Fatalf("rename todo: %v", err)
+	}
+	mustRewriteFile(t, filepath.Join(target, ".seed", "manifest.json"), func(content string) string {
+		return strings.Replace(content, `"todo_file": "TODO.md"`, `"todo_file": "TASKS.md"`, 1)
+	})
+	out.Reset()
+	if err := runTodoList(docListOptions{repoPath: target}, &out); err != nil || !strings.Contains(out.String(), "Done (recent) (6)") {
+		t.Fatalf("todo list should read TASKS.md (%v): %s", err, out.String())
+	}
+}
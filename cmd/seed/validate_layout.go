@@ -8,7 +8,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // validateLayoutOptions configures the profile-aware layout validator.
@@ -16,6 +18,14 @@ type validateLayoutOptions struct {
 	repoPath   string
 	profile    string
 	profileSet bool
+	// staleness adds git-history drift checks on top of the profile rules.
+	staleness bool
+	// rev validates a single commit straight from git objects instead of the working tree.
+	rev string
+	// rangeSpec validates every commit in an "A..B" range, oldest first.
+	rangeSpec string
+	// watch re-runs the Go rules whenever watched files change, until interrupted.
+	watch bool
 }
 
 func parseValidateLayoutArgs(opts options, args []string) (options, error) {
@@ -33,6 +43,25 @@ func parseValidateLayoutArgs(opts options, args []string) (options, error) {
 			opts.validate.profile = strings.TrimSpace(args[i+1])
 			opts.validate.profileSet = true
 			i++
+		case "--staleness":
+			opts.validate.staleness = true
+		case "--watch":
+			opts.validate.watch = true
+		case "--rev":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("missing value for --rev")
+			}
+			opts.validate.rev = strings.TrimSpace(args[i+1])
+			i++
+		case "--range":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("missing value for --range")
+			}
+			opts.validate.rangeSpec = strings.TrimSpace(args[i+1])
+			if !strings.Contains(opts.validate.rangeSpec, "..") {
+				return opts, fmt.Errorf("invalid --range %q (expected A..B)", opts.validate.rangeSpec)
+			}
+			i++
 		case "-h", "--help":
 			opts.showHelp = true
 		default:
@@ -40,19 +69,43 @@ func parseValidateLayoutArgs(opts options, args []string) (options, error) {
 		}
 	}
 
+	if opts.validate.rev != "" && opts.validate.rangeSpec != "" {
+		return opts, fmt.Errorf("--rev and --range cannot be combined")
+	}
+	if opts.validate.staleness && (opts.validate.rev != "" || opts.validate.rangeSpec != "") {
+		return opts, fmt.Errorf("--staleness only applies to the working tree")
+	}
+	if opts.validate.watch && (opts.validate.staleness || opts.validate.rev != "" || opts.validate.rangeSpec != "") {
+		return opts, fmt.Errorf("--watch cannot be combined with --staleness, --rev or --range")
+	}
+
 	return opts, nil
 }
 
 func printValidateLayoutUsage(w io.Writer) {
-	fmt.Fprintln(w, "Usage: seed validate-layout [repo-path] [--profile core|llm|guarded]")
+	fmt.Fprintln(w, "Usage: seed validate-layout [repo-path] [--profile core|llm|guarded] [--staleness | --rev <commit> | --range A..B | --watch]")
 	fmt.Fprintln(w)
 	fmt.Fprintln(w, "Validate that a repository matches a Seed profile contract.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	fmt.Fprintln(w, "  --staleness  Also flag docs that drifted from code using git history (warnings only)")
+	fmt.Fprintln(w, "  --rev        Validate a commit or branch using that revision's .seed/manifest.json")
+	fmt.Fprintln(w, "  --range      Validate each commit in A..B and report the first breaking commit")
+	fmt.Fprintln(w, "  --watch      Re-run affected rules on every change until Ctrl-C (JSON lines when not a TTY)")
 }
 
 func runValidateLayout(opts validateLayoutOptions, out, errOut io.Writer) int {
+	if opts.rev != "" {
+		return runValidateRevision(opts, out, errOut)
+	}
+	if opts.rangeSpec != "" {
+		return runValidateRange(opts, out, errOut)
+	}
+
+	source := worktreeSource{root: opts.repoPath}
 	profile := opts.profile
 	if !opts.profileSet {
-		inferred, err := inferSeedProfile(opts.repoPath)
+		inferred, err := inferSeedProfile(source)
 		if err != nil {
 			fmt.Fprintf(errOut, "Failed to infer profile: %s\n", err)
 			return 1
@@ -65,29 +118,62 @@ func runValidateLayout(opts validateLayoutOptions, out, errOut io.Writer) int {
 		return 1
 	}
 
-	requiredFiles := []string{
-		"README.md",
-		"DECISIONS.md",
-		"TODO.md",
-		"CONTEXT.md",
-		"AGENTS.md",
+	code := 0
+	if profile == profileGuarded {
+		code = runGuardedSeedTest(opts.repoPath, profile, out, errOut)
+	} else {
+		code = validateLayoutRules(source, profile, time.Now(), out, errOut)
 	}
-	if profile == profileLLM || profile == profileGuarded {
-		requiredFiles = append(requiredFiles,
-			".seed/manifest.json",
-			"skills/seed-validate/SKILL.md",
-		)
+	if code != 0 && code != 2 {
+		return code
 	}
-	if profile == profileGuarded {
-		requiredFiles = append(requiredFiles,
-			".seed/seed-test.sh",
-			".seed/install-hooks.sh",
-			".seed/hooks/pre-commit",
-		)
+
+	if opts.staleness {
+		rules, _, err := loadRepoRules(source, profile)
+		if err != nil {
+			fmt.Fprintf(errOut, "Failed to load Seed rules: %s\n", err)
+			return 1
+		}
+		// Invalid overrides were already reported by the layout run above.
+		overrides, _ := loadOverrides(source)
+		report := layoutReport{}
+		checkStaleness(opts.repoPath, rules, &report)
+		report.Findings = adjustFindings(report.Findings, rules.RuleSeverities, overrides)
+		for _, finding := range report.Findings {
+			fmt.Fprintln(errOut, finding.Message)
+		}
+		// Staleness stays advisory, so a rule raised to error is still counted as a warning.
+		stale := report.count(severityWarning) + report.count(severityError)
+		fmt.Fprintf(out, "SEED_STALENESS_WARNINGS=%d\n", stale)
+		if stale > 0 {
+			code = 2
+		}
+	}
+
+	if code == 2 {
+		fmt.Fprintln(out, "seed-layout-validation: warnings present, skill recommended")
 	}
+	fmt.Fprintf(out, "seed-layout-validation: ok (profile=%s)\n", profile)
+	return 0
+}
 
-	for _, relativePath := range requiredFiles {
-		fullPath := filepath.Join(opts.repoPath, relativePath)
+// runGuardedSeedTest delegates to the repo's own seed-test.sh and returns its exit code.
+// The required files come from the repo's manifest, the same list seed-test.sh reads from rules.tsv.
+func runGuardedSeedTest(repoPath, profile string, out, errOut io.Writer) int {
+	source := worktreeSource{root: repoPath}
+	rules, _, err := loadRepoRules(source, profile)
+	if err != nil {
+		fmt.Fprintf(errOut, "Failed to load Seed rules: %s\n", err)
+		return 1
+	}
+	// seed-test.sh reports invalid overrides itself as invalid_overrides.
+	overrides, _ := loadOverrides(source)
+	exempt := compileGlobs(overrides.ExemptFiles)
+	for _, relativePath := range rules.RequiredFiles {
+		if exempt.match(relativePath) {
+			continue
+		}
+		fullPath := filepath.Join(repoPath, filepath.FromSlash(relativePath))
 		info, err := os.Stat(fullPath)
 		if err != nil || info.IsDir() {
 			fmt.Fprintf(errOut, "Missing required Seed artifact (%s): %s\n", profile, relativePath)
@@ -95,46 +181,130 @@ func runValidateLayout(opts validateLayoutOptions, out, errOut io.Writer) int {
 		}
 	}
 
-	if profile == profileGuarded {
-		// Guarded repos intentionally stay self-contained and validate via generated scripts.
-		seedScript := filepath.Join(opts.repoPath, ".seed", "seed-test.sh")
-		seedTestCmd := exec.Command(seedScript)
-		if info, err := os.Stat(seedScript); err == nil && info.Mode()&0o111 == 0 {
-			seedTestCmd = exec.Command("sh", seedScript)
-		}
-		seedOutput, seedCode, err := commandWithExit(seedTestCmd)
-		if err != nil {
-			fmt.Fprintf(errOut, "%s\n", err)
-			return 1
+	// Guarded repos intentionally stay self-contained and validate via generated scripts.
+	seedScript := filepath.Join(repoPath, ".seed", "seed-test.sh")
+	seedTestCmd := exec.Command(seedScript)
+	if info, err := os.Stat(seedScript); err == nil && info.Mode()&0o111 == 0 {
+		seedTestCmd = exec.Command("sh", seedScript)
+	}
+	seedOutput, seedCode, err := commandWithExit(seedTestCmd)
+	if err != nil {
+		fmt.Fprintf(errOut, "%s\n", err)
+		return 1
+	}
+	seedOutput, seedCode = addFormatCheck(seedOutput, seedCode, rules, overrides, errOut)
+	if seedOutput != "" {
+		fmt.Fprint(out, seedOutput)
+		if !strings.HasSuffix(seedOutput, "\n") {
+			fmt.Fprintln(out)
 		}
-		if seedOutput != "" {
-			fmt.Fprint(out, seedOutput)
-			if !strings.HasSuffix(seedOutput, "\n") {
-				fmt.Fprintln(out)
+	}
+	return seedCode
+}
+
+// addFormatCheck adds the CLI's format check to seed-test.sh output. A script only knows
+// the format it was generated for, so an outdated script passes a repo whose format is just as old.
+func addFormatCheck(seedOutput string, seedCode int, rules manifestSnapshot, overrides ruleOverrides, errOut io.Writer) (string, int) {
+	fields := parseSeedStatus(seedOutput)
+	status := fields["SEED_STATUS"]
+	reasons := make([]string, 0)
+	if value := fields["SEED_TRIGGER_REASONS"]; value != "" && value != "none" {
+		reasons = strings.Split(value, ",")
+	}
+	if status == "" || containsString(reasons, reasonFormatOutdated) {
+		return seedOutput, seedCode
+	}
+	report := layoutReport{}
+	checkFormatVersion(rules, &report)
+	errorCount, _ := strconv.Atoi(fields["SEED_ERRORS"])
+	warningCount, _ := strconv.Atoi(fields["SEED_WARNINGS"])
+	added := false
+	for _, finding := range adjustFindings(report.Findings, rules.RuleSeverities, overrides) {
+		switch {
+		case finding.Severity == severityError || (finding.Severity == severityWarning && rules.WarningsAsErrors):
+			errorCount++
+			status = statusFail
+		case finding.Severity == severityWarning:
+			warningCount++
+			if status == statusOK {
+				status = statusSkillRecommended
 			}
+		default:
+			continue
 		}
+		fmt.Fprintln(errOut, finding.Message)
+		reasons = append(reasons, finding.Reason)
+		added = true
+	}
+	if !added {
+		return seedOutput, seedCode
+	}
 
-		switch seedCode {
-		case 0:
-		case 2:
-			fmt.Fprintln(out, "seed-layout-validation: warnings present, skill recommended")
+	// The script's status block is replaced in place; other output lines are kept.
+	var builder strings.Builder
+	for _, line := range strings.SplitAfter(seedOutput, "\n") {
+		key, _, _ := strings.Cut(strings.TrimSuffix(line, "\n"), "=")
+		switch key {
+		case "SEED_STATUS":
+			printSeedStatusCounts(&builder, status, errorCount, warningCount, reasons)
+		case "SEED_ERRORS", "SEED_WARNINGS", "SEED_TRIGGER_REASONS", "SEED_NEXT_ACTION", "SEED_VALIDATE_SKILL":
 		default:
-			return seedCode
+			builder.WriteString(line)
 		}
 	}
+	switch status {
+	case statusFail:
+		seedCode = 1
+	case statusSkillRecommended:
+		seedCode = 2
+	}
+	return builder.String(), seedCode
+}
 
-	fmt.Fprintf(out, "seed-layout-validation: ok (profile=%s)\n", profile)
-	return 0
+// validateLayoutRules runs the Go port of the seed-test.sh rules for profiles without a local script.
+func validateLayoutRules(source repoSource, profile string, today time.Time, out, errOut io.Writer) int {
+	rules, _, err := loadRepoRules(source, profile)
+	if err != nil {
+		fmt.Fprintf(errOut, "Failed to load Seed rules: %s\n", err)
+		return 1
+	}
+
+	report := validateContract(source, rules, contractOptions{today: today})
+	for _, finding := range report.Findings {
+		fmt.Fprintln(errOut, finding.Message)
+	}
+	status, reasons, code := report.outcome(rules.WarningsAsErrors)
+	printSeedStatus(out, status, report, reasons)
+	return code
 }
 
-func inferSeedProfile(repoPath string) (string, error) {
-	if pathExists(filepath.Join(repoPath, ".seed", "seed-test.sh")) {
+// printSeedStatus emits the same key=value contract as seed-test.sh.
+func printSeedStatus(w io.Writer, status string, report layoutReport, reasons []string) {
+	printSeedStatusCounts(w, status, report.count(severityError), report.count(severityWarning), reasons)
+}
+
+func printSeedStatusCounts(w io.Writer, status string, errorCount, warningCount int, reasons []string) {
+	reasonList := "none"
+	if len(reasons) > 0 {
+		reasonList = strings.Join(reasons, ",")
+	}
+	fmt.Fprintf(w, "SEED_STATUS=%s\n", status)
+	fmt.Fprintf(w, "SEED_ERRORS=%d\n", errorCount)
+	fmt.Fprintf(w, "SEED_WARNINGS=%d\n", warningCount)
+	fmt.Fprintf(w, "SEED_TRIGGER_REASONS=%s\n", reasonList)
+	if status == statusSkillRecommended {
+		fmt.Fprintln(w, "SEED_NEXT_ACTION=run_seed_validate_skill")
+		fmt.Fprintln(w, "SEED_VALIDATE_SKILL=skills/seed-validate/SKILL.md")
+	}
+}
+
+func inferSeedProfile(source repoSource) (string, error) {
+	if source.isFile(".seed/seed-test.sh") {
 		return profileGuarded, nil
 	}
 
-	manifestPath := filepath.Join(repoPath, ".seed", "manifest.json")
-	if pathExists(manifestPath) {
-		manifest, err := parseManifestProfile(manifestPath)
+	if source.isFile(".seed/manifest.json") {
+		manifest, err := parseManifestProfile(source)
 		if err != nil {
 			return profileLLM, nil
 		}
@@ -147,12 +317,12 @@ func inferSeedProfile(repoPath string) (string, error) {
 	return profileCore, nil
 }
 
-func parseManifestProfile(manifestPath string) (string, error) {
+func parseManifestProfile(source repoSource) (string, error) {
 	type profileManifest struct {
 		ActiveProfile string `json:"active_profile"`
 	}
 
-	bytes, err := os.ReadFile(manifestPath)
+	bytes, err := source.readFile(".seed/manifest.json")
 	if err != nil {
 		return "", err
 	}
@@ -163,11 +333,6 @@ func parseManifestProfile(manifestPath string) (string, error) {
 	return strings.TrimSpace(manifest.ActiveProfile), nil
 }
 
-func pathExists(path string) bool {
-	_, err := os.Stat(path)
-	return err == nil
-}
-
 func commandWithExit(cmd *exec.Cmd) (string, int, error) {
 	output, err := cmd.CombinedOutput()
 	if err == nil {
@@ -0,0 +1,490 @@
+package main
+
+// Watch mode re-runs the Go layout rules whenever watched files change.
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultWatchInterval = 300 * time.Millisecond
+	defaultWatchDebounce = 500 * time.Millisecond
+	defaultWatchRelist   = 3 * time.Second
+)
+
+// Rule groups in the order validateContract runs them.
+const (
+	ruleGroupOverrides     = "overrides"
+	ruleGroupRulesFile     = "rules_file"
+	ruleGroupRequiredFiles = "required_files"
+	ruleGroupFileRules     = "file_rules"
+	ruleGroupHeadings      = "headings"
+	ruleGroupContent       = "content"
+	ruleGroupDecisions     = "decisions"
+	ruleGroupTodo          = "todo"
+	ruleGroupMisplaced     = "misplaced_content"
+)
+
+var ruleGroupOrder = []string{
+	ruleGroupOverrides, ruleGroupRulesFile, ruleGroupRequiredFiles, ruleGroupFileRules, ruleGroupHeadings,
+	ruleGroupContent, ruleGroupDecisions, ruleGroupTodo, ruleGroupMisplaced,
+}
+
+type watchConfig struct {
+	// interval is how often the watched files are polled.
+	interval time.Duration
+	// debounce is how long the files must stay unchanged before rules re-run.
+	debounce time.Duration
+	// relist is how often the repo file list is re-read and the scanned files stat'ed;
+	// in between, polls only stat the files the rule groups read.
+	relist time.Duration
+	// color clears the screen and colours findings; otherwise results are JSON lines.
+	color bool
+}
+
+type watchResult struct {
+	Time           string          `json:"time"`
+	Changed        []string        `json:"changed"`
+	Rules          []string        `json:"rules"`
+	Status         string          `json:"status"`
+	Errors         int             `json:"errors"`
+	Warnings       int             `json:"warnings"`
+	TriggerReasons []string        `json:"trigger_reasons"`
+	New            []layoutFinding `json:"new"`
+	Resolved       []layoutFinding `json:"resolved"`
+	Findings       []layoutFinding `json:"findings"`
+	Error          string          `json:"error,omitempty"`
+}
+
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// layoutWatcher caches findings per rule group, and per file for misplaced
+// content, so a change only re-runs the rules that read the changed files.
+type layoutWatcher struct {
+	source    worktreeSource
+	profile   string
+	rules     manifestSnapshot
+	overrides ruleOverrides
+	groups    map[string][]layoutFinding
+	scanned   map[string][]layoutFinding
+	previous  []layoutFinding
+	// listed stamps the repo files in the misplaced-content scope or named by a
+	// file rule glob; listedAt is when they were last listed.
+	listed   map[string]fileStamp
+	listedAt time.Time
+}
+
+// filteredSource limits listFiles to the given paths, so checkMisplacedContent scans only changed files.
+type filteredSource struct {
+	repoSource
+	files []string
+}
+
+func (s filteredSource) listFiles() []string {
+	return s.files
+}
+
+func runValidateWatch(ctx context.Context, opts validateLayoutOptions, cfg watchConfig, out, errOut io.Writer) int {
+	source := worktreeSource{root: opts.repoPath}
+	profile := opts.profile
+	if !opts.profileSet {
+		inferred, err := inferSeedProfile(source)
+		if err != nil {
+			fmt.Fprintf(errOut, "Failed to infer profile: %s\n", err)
+			return 1
+		}
+		profile = inferred
+	}
+	if !validProfiles[profile] {
+		fmt.Fprintf(errOut, "Invalid profile: %s (expected core|llm|guarded)\n", profile)
+		return 1
+	}
+
+	watcher := &layoutWatcher{source: source, profile: profile}
+	result := watcher.run(nil)
+	// Snapshot before printing so edits made in reaction to the output are seen.
+	last, ok := watcher.snapshot(ctx, true)
+	if !ok {
+		return 0
+	}
+	printWatchResult(out, cfg, profile, result)
+
+	ticker := time.NewTicker(cfg.interval)
+	defer ticker.Stop()
+	pending := map[string]bool{}
+	var lastChange time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			if cfg.color {
+				fmt.Fprintln(out)
+			}
+			return 0
+		case <-ticker.C:
+		}
+
+		current, ok := watcher.snapshot(ctx, time.Since(watcher.listedAt) >= cfg.relist)
+		if !ok {
+			continue
+		}
+		if changed := changedPaths(last, current); len(changed) > 0 {
+			for _, path := range changed {
+				pending[path] = true
+			}
+			last = current
+			lastChange = time.Now()
+			continue
+		}
+		if len(pending) == 0 || time.Since(lastChange) < cfg.debounce {
+			continue
+		}
+		changed := make([]string, 0, len(pending))
+		for path := range pending {
+			changed = append(changed, path)
+		}
+		sort.Strings(changed)
+		pending = map[string]bool{}
+		result := watcher.run(changed)
+		if last, ok = watcher.snapshot(ctx, true); !ok {
+			continue
+		}
+		printWatchResult(out, cfg, profile, result)
+	}
+}
+
+// run re-runs the rule groups affected by changed paths; nil means everything.
+func (w *layoutWatcher) run(changed []string) watchResult {
+	result := watchResult{Time: time.Now().Format(time.RFC3339), Changed: changed}
+	if changed == nil {
+		result.Changed = []string{}
+	}
+
+	// Overrides change the rules every group runs with, like the manifest itself.
+	all := changed == nil || containsString(changed, ".seed/manifest.json") || containsString(changed, overridesPath) || w.groups == nil
+	if all {
+		rules, _, err := loadRepoRules(w.source, w.profile)
+		if err != nil {
+			result.Error = fmt.Sprintf("Failed to load Seed rules: %s", err)
+			result.Status = statusFail
+			w.groups = nil
+			w.scanned = nil
+			return w.finish(result)
+		}
+		report := layoutReport{}
+		w.overrides = checkOverrides(w.source, &report)
+		w.rules = w.overrides.apply(rules)
+		w.groups = map[string][]layoutFinding{ruleGroupOverrides: report.Findings}
+		w.scanned = map[string][]layoutFinding{}
+		result.Rules = append(result.Rules, ruleGroupOverrides)
+	}
+
+	opts := contractOptions{today: time.Now()}
+	for _, group := range ruleGroupOrder {
+		if group == ruleGroupOverrides || group == ruleGroupMisplaced {
+			continue
+		}
+		if group == ruleGroupHeadings && !w.rules.checksHeadings() {
+			continue
+		}
+		stale := anyPathIn(changed, w.groupFiles(group))
+		if group == ruleGroupFileRules {
+			// A created or deleted file is not in the current list either way, so match the globs.
+			stale = false
+			for _, path := range changed {
+				stale = stale || w.matchesFileRule(path)
+			}
+		}
+		if !all && !stale {
+			continue
+		}
+		report := layoutReport{}
+		switch group {
+		case ruleGroupRulesFile:
+			checkFormatVersion(w.rules, &report)
+			checkRulesFile(w.source, &report)
+		case ruleGroupRequiredFiles:
+			checkRequiredFiles(w.source, w.rules, &report)
+		case ruleGroupFileRules:
+			checkFileRules(w.source, w.rules, &report)
+		case ruleGroupHeadings:
+			checkRequiredHeadings(w.source, w.rules, &report)
+		case ruleGroupContent:
+			checkContentRules(w.source, w.rules, opts, &report)
+		case ruleGroupDecisions:
+			checkDecisions(w.source, w.rules, &report)
+		case ruleGroupTodo:
+			checkTodo(w.source, w.rules, &report)
+		}
+		w.groups[group] = report.Findings
+		result.Rules = append(result.Rules, group)
+	}
+
+	var scan []string
+	rescan := all
+	if all {
+		scan = w.source.listFiles()
+	} else {
+		scan = make([]string, 0, len(changed))
+		for _, path := range changed {
+			_, cached := w.scanned[path]
+			inScope := w.rules.MisplacedContentScope.classify(path) != ""
+			rescan = rescan || cached || inScope
+			delete(w.scanned, path)
+			if inScope && w.source.isFile(path) {
+				scan = append(scan, path)
+			}
+		}
+	}
+	if rescan {
+		report := layoutReport{}
+		checkMisplacedContent(filteredSource{repoSource: w.source, files: scan}, w.rules, &report)
+		for _, finding := range report.Findings {
+			w.scanned[finding.File] = append(w.scanned[finding.File], finding)
+		}
+		result.Rules = append(result.Rules, ruleGroupMisplaced)
+	}
+	if result.Rules == nil {
+		result.Rules = []string{}
+	}
+	return w.finish(result)
+}
+
+// finish assembles cached findings in validateContract order and diffs them with the previous run.
+func (w *layoutWatcher) finish(result watchResult) watchResult {
+	report := layoutReport{}
+	for _, group := range ruleGroupOrder {
+		if group != ruleGroupMisplaced {
+			report.Findings = append(report.Findings, w.groups[group]...)
+		}
+	}
+	scannedFiles := make([]string, 0, len(w.scanned))
+	for path := range w.scanned {
+		scannedFiles = append(scannedFiles, path)
+	}
+	// Text files are reported before source files, each in path order.
+	sort.SliceStable(scannedFiles, func(i, j int) bool {
+		ki := w.rules.MisplacedContentScope.classify(scannedFiles[i]) == scanComments
+		kj := w.rules.MisplacedContentScope.classify(scannedFiles[j]) == scanComments
+		if ki != kj {
+			return !ki
+		}
+		return scannedFiles[i] < scannedFiles[j]
+	})
+	for _, path := range scannedFiles {
+		report.Findings = append(report.Findings, w.scanned[path]...)
+	}
+
+	report.Findings = adjustFindings(report.Findings, w.rules.RuleSeverities, w.overrides)
+
+	if result.Error == "" {
+		result.Status, result.TriggerReasons, _ = report.outcome(w.rules.WarningsAsErrors)
+	}
+	if result.TriggerReasons == nil {
+		result.TriggerReasons = []string{}
+	}
+	result.Errors = report.count(severityError)
+	result.Warnings = report.count(severityWarning)
+	result.Findings = append([]layoutFinding{}, report.Findings...)
+	result.New = findingsMissingFrom(result.Findings, w.previous)
+	result.Resolved = findingsMissingFrom(w.previous, result.Findings)
+	w.previous = result.Findings
+	return result
+}
+
+// groupFiles lists the files a rule group reads.
+func (w *layoutWatcher) groupFiles(group string) []string {
+	files := make([]string, 0)
+	switch group {
+	case ruleGroupOverrides:
+		files = append(files, overridesPath)
+	case ruleGroupRulesFile:
+		files = append(files, ".seed/manifest.json", rulesFilePath)
+	case ruleGroupRequiredFiles:
+		files = append(files, w.rules.RequiredFiles...)
+	case ruleGroupHeadings:
+		for _, spec := range w.rules.RequiredHeadings {
+			file, _ := splitHeadingSpec(spec)
+			files = append(files, file)
+		}
+	case ruleGroupContent:
+		files = append(files, seedDocs...)
+		for _, spec := range append(append([]string{}, w.rules.NonEmptySections...), w.rules.RequiredListFields...) {
+			file, _ := splitHeadingSpec(spec)
+			files = append(files, file)
+		}
+	case ruleGroupDecisions:
+		files = append(files, w.rules.DecisionsFile)
+	case ruleGroupTodo:
+		files = append(files, w.rules.TodoFile)
+	}
+	return files
+}
+
+// matchesFileRule reports whether a require_any or forbid glob names path.
+func (w *layoutWatcher) matchesFileRule(path string) bool {
+	for _, rule := range w.rules.FileRules {
+		if rule.matches(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshot stamps every file some rule group reads, plus the files in the
+// misplaced-content scope or named by a file rule. Those are listed and stat'ed
+// only when relist is set; other polls reuse their stamps. It returns false when ctx ends.
+func (w *layoutWatcher) snapshot(ctx context.Context, relist bool) (map[string]fileStamp, bool) {
+	if relist || w.listed == nil {
+		listed := make([]string, 0)
+		for _, path := range w.source.listFiles() {
+			if w.rules.MisplacedContentScope.classify(path) != "" || w.matchesFileRule(path) {
+				listed = append(listed, path)
+			}
+		}
+		stamps, ok := w.stamp(ctx, listed, map[string]fileStamp{})
+		if !ok {
+			return nil, false
+		}
+		w.listed, w.listedAt = stamps, time.Now()
+	}
+
+	paths := append([]string{".seed/manifest.json"}, seedDocs...)
+	for _, group := range ruleGroupOrder {
+		paths = append(paths, w.groupFiles(group)...)
+	}
+	stamps := make(map[string]fileStamp, len(w.listed)+len(paths))
+	for path, stamp := range w.listed {
+		stamps[path] = stamp
+	}
+	return w.stamp(ctx, paths, stamps)
+}
+
+// stamp adds the size and modification time of each existing file to stamps.
+func (w *layoutWatcher) stamp(ctx context.Context, paths []string, stamps map[string]fileStamp) (map[string]fileStamp, bool) {
+	for i, path := range paths {
+		if i%256 == 0 && ctx.Err() != nil {
+			return nil, false
+		}
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(w.source.root, filepath.FromSlash(path)))
+		if err != nil || info.IsDir() {
+			delete(stamps, path)
+			continue
+		}
+		stamps[path] = fileStamp{size: info.Size(), modTime: info.ModTime()}
+	}
+	return stamps, true
+}
+
+func changedPaths(before, after map[string]fileStamp) []string {
+	changed := make([]string, 0)
+	for path, stamp := range after {
+		if previous, ok := before[path]; !ok || previous != stamp {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func anyPathIn(paths, files []string) bool {
+	for _, path := range paths {
+		if containsString(files, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func findingsMissingFrom(findings, other []layoutFinding) []layoutFinding {
+	missing := make([]layoutFinding, 0)
+	for _, finding := range findings {
+		if !containsFinding(other, finding) {
+			missing = append(missing, finding)
+		}
+	}
+	return missing
+}
+
+func containsFinding(findings []layoutFinding, finding layoutFinding) bool {
+	for _, candidate := range findings {
+		if candidate == finding {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	ansiClear  = "\033[H\033[2J"
+	ansiRed    = "\033[31m"
+	ansiYellow = "\033[33m"
+	ansiGreen  = "\033[32m"
+	ansiDim    = "\033[2m"
+	ansiReset  = "\033[0m"
+)
+
+func printWatchResult(out io.Writer, cfg watchConfig, profile string, result watchResult) {
+	if !cfg.color {
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(out, string(encoded))
+		return
+	}
+
+	fmt.Fprint(out, ansiClear)
+	fmt.Fprintf(out, "seed validate-layout --watch (profile=%s) %s\n", profile, result.Time)
+	if len(result.Changed) > 0 {
+		fmt.Fprintf(out, "changed: %s\n", strings.Join(result.Changed, ", "))
+		fmt.Fprintf(out, "re-ran: %s\n", strings.Join(result.Rules, ", "))
+	}
+	fmt.Fprintln(out)
+	if result.Error != "" {
+		fmt.Fprintf(out, "%s%s%s\n", ansiRed, result.Error, ansiReset)
+	}
+	for _, finding := range result.Findings {
+		color := ansiDim
+		switch finding.Severity {
+		case severityError:
+			color = ansiRed
+		case severityWarning:
+			color = ansiYellow
+		}
+		marker := " "
+		if len(result.Changed) > 0 && containsFinding(result.New, finding) {
+			marker = "+"
+		}
+		fmt.Fprintf(out, "%s %s%s%s\n", marker, color, finding.Message, ansiReset)
+	}
+	for _, finding := range result.Resolved {
+		fmt.Fprintf(out, "%s✓ %s%s\n", ansiGreen, finding.Message, ansiReset)
+	}
+	if result.Error == "" {
+		reasons := "none"
+		if len(result.TriggerReasons) > 0 {
+			reasons = strings.Join(result.TriggerReasons, ",")
+		}
+		fmt.Fprintf(out, "\nSEED_STATUS=%s SEED_ERRORS=%d SEED_WARNINGS=%d SEED_TRIGGER_REASONS=%s\n",
+			result.Status, result.Errors, result.Warnings, reasons)
+	}
+	fmt.Fprintln(out, "Watching for changes; press Ctrl-C to stop.")
+}
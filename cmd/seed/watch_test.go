@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestValidateWatch(t *testing.T) {
+	requireGit(t)
+
+	// Heading checks only run in guarded repos, so watch one to see every rule group.
+	manifest := mustLoadManifest(t)
+	target := filepath.Join(t.TempDir(), "guarded")
+	mustScaffoldGitRepo(t, target, profileGuarded, manifest)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := &lockedBuffer{}
+	done := make(chan int, 1)
+	go func() {
+		cfg := watchConfig{interval: 10 * time.Millisecond, debounce: 50 * time.Millisecond, relist: 20 * time.Millisecond}
+		done <- runValidateWatch(ctx, validateLayoutOptions{repoPath: target}, cfg, out, io.Discard)
+	}()
+
+	results := func() []watchResult {
+		parsed := make([]watchResult, 0)
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			var result watchResult
+			if line != "" && json.Unmarshal([]byte(line), &result) == nil {
+				parsed = append(parsed, result)
+			}
+		}
+		return parsed
+	}
+	waitFor := func(count int) []watchResult {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if parsed := results(); len(parsed) >= count {
+				return parsed
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("timed out waiting for %d watch results:\n%s", count, out.String())
+		return nil
+	}
+
+	initial := waitFor(1)[0]
+	if initial.Status != statusOK || len(initial.Rules) != len(ruleGroupOrder) {
+		t.Fatalf("unexpected initial result: %+v", initial)
+	}
+
+	// Two quick saves are debounced into one run that only rescans the new file.
+	guide := filepath.Join(target, "docs", "guide.md")
+	if err := writeFile(guide, "# Guide\n", 0o644); err != nil {
+		t.Fatalf("write guide: %v", err)
+	}
+	if err := writeFile(guide, "# Guide\n\n## Current Status\n\nShipping.\n", 0o644); err != nil {
+		t.Fatalf("rewrite guide: %v", err)
+	}
+	second := waitFor(2)[1]
+	if strings.Join(second.Changed, ",") != "docs/guide.md" || strings.Join(second.Rules, ",") != ruleGroupMisplaced ||
+		second.Status != statusSkillRecommended || len(second.New) != 1 || second.New[0].Reason != reasonMisplacedContent {
+		t.Fatalf("unexpected result after editing docs/guide.md: %+v", second)
+	}
+
+	mustRewriteFile(t, filepath.Join(target, "README.md"), func(content string) string {
+		return strings.Replace(content, "## Quick Start\n", "## Quik Start\n", 1)
+	})
+	third := waitFor(3)[2]
+	if !containsString(third.Rules, ruleGroupHeadings) || containsString(third.Rules, ruleGroupTodo) ||
+		strings.Join(third.TriggerReasons, ",") != "heading_near_miss,misplaced_content" {
+		t.Fatalf("unexpected result after editing README.md: %+v", third)
+	}
+
+	cancel()
+	select {
+	case code := <-done:
+		if code != 0 {
+			t.Fatalf("watch should exit cleanly when cancelled, got %d", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch did not stop after cancel")
+	}
+	if got := len(results()); got != 3 {
+		t.Fatalf("expected exactly 3 results, got %d:\n%s", got, out.String())
+	}
+}
+
+// lockedBuffer is a bytes.Buffer that is safe to write from the watch goroutine.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -4,5 +4,5 @@ import "embed"
 
 // FS embeds canonical Seed assets into the CLI binary so seeded repos are self-contained.
 //
-//go:embed seed-contract/manifest.json skills/seed-validate/SKILL.md
+//go:embed seed-contract/manifest.json seed-contract/manifest.schema.json seed-contract/seeded-manifest.schema.json seed-contract/overrides.schema.json skills/seed-validate/SKILL.md
 var FS embed.FS